@@ -0,0 +1,212 @@
+package qbit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrTorrentAddFailed is returned when qBittorrent refuses to add a torrent,
+// e.g. because it is invalid or already added.
+var ErrTorrentAddFailed = fmt.Errorf("torrent could not be added: %w", ErrActionFailed)
+
+// ContentLayout is how the content of an added torrent is laid out on disk.
+type ContentLayout string
+
+//noinspection GoUnusedConst
+const (
+	ContentLayoutOriginal    ContentLayout = "Original"    // Keep the layout of the torrent
+	ContentLayoutSubfolder   ContentLayout = "Subfolder"   // Always create a subfolder
+	ContentLayoutNoSubfolder ContentLayout = "NoSubfolder" // Never create a subfolder
+)
+
+// AddTorrentOptions are the settings of a torrent being added. Zero values and
+// nil pointers are not sent, so qBittorrent falls back to its own defaults for them.
+type AddTorrentOptions struct {
+	SavePath               string        // Download folder
+	Category               string        // Category of the torrent
+	Tags                   []string      // Tags of the torrent
+	Paused                 bool          // Add the torrent in paused state
+	SkipChecking           bool          // Skip hash checking
+	RootFolder             *bool         // Create the root folder, replaced by ContentLayout in newer versions
+	ContentLayout          ContentLayout // Layout of the content on disk
+	Rename                 string        // Rename the torrent
+	UpLimit                int64         // Upload speed limit (bytes/s)
+	DlLimit                int64         // Download speed limit (bytes/s)
+	RatioLimit             float32       // Share ratio limit
+	SeedingTimeLimit       int32         // Seeding time limit (minutes)
+	AutoTMM                *bool         // Whether Automatic Torrent Management should be used
+	SequentialDownload     bool          // Download the pieces in sequential order
+	FirstLastPiecePriority bool          // Prioritize the first and last pieces
+}
+
+func (o *AddTorrentOptions) writeFields(w *multipart.Writer) error {
+	var fields [][2]string
+	add := func(name, value string) {
+		fields = append(fields, [2]string{name, value})
+	}
+
+	if o.SavePath != "" {
+		add("savepath", o.SavePath)
+	}
+	if o.Category != "" {
+		add("category", o.Category)
+	}
+	if len(o.Tags) > 0 {
+		add("tags", strings.Join(o.Tags, ","))
+	}
+	if o.Paused {
+		add("paused", "true")
+	}
+	if o.SkipChecking {
+		add("skip_checking", "true")
+	}
+	if o.RootFolder != nil {
+		add("root_folder", strconv.FormatBool(*o.RootFolder))
+	}
+	if o.ContentLayout != "" {
+		add("contentLayout", string(o.ContentLayout))
+	}
+	if o.Rename != "" {
+		add("rename", o.Rename)
+	}
+	if o.UpLimit != 0 {
+		add("upLimit", strconv.FormatInt(o.UpLimit, 10))
+	}
+	if o.DlLimit != 0 {
+		add("dlLimit", strconv.FormatInt(o.DlLimit, 10))
+	}
+	if o.RatioLimit != 0 {
+		add("ratioLimit", strconv.FormatFloat(float64(o.RatioLimit), 'f', -1, 32))
+	}
+	if o.SeedingTimeLimit != 0 {
+		add("seedingTimeLimit", strconv.FormatInt(int64(o.SeedingTimeLimit), 10))
+	}
+	if o.AutoTMM != nil {
+		add("autoTMM", strconv.FormatBool(*o.AutoTMM))
+	}
+	if o.SequentialDownload {
+		add("sequentialDownload", "true")
+	}
+	if o.FirstLastPiecePriority {
+		add("firstLastPiecePrio", "true")
+	}
+
+	for _, field := range fields {
+		if err := w.WriteField(field[0], field[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddTorrentURLs adds the torrents of magnet URIs and of .torrent files that
+// qBittorrent downloads from http(s) urls.
+func (c *Client) AddTorrentURLs(ctx context.Context, urls []string, opts AddTorrentOptions) error {
+	if len(urls) == 0 {
+		return &Error{Message: "no torrent urls given"}
+	}
+
+	return c.addTorrent(ctx, opts, func(w *multipart.Writer) error {
+		return w.WriteField("urls", strings.Join(urls, "\n"))
+	})
+}
+
+// AddTorrentByMagnet adds the torrent of a magnet URI.
+func (c *Client) AddTorrentByMagnet(ctx context.Context, magnetUri string, opts AddTorrentOptions) error {
+	return c.AddTorrentURLs(ctx, []string{magnetUri}, opts)
+}
+
+// AddTorrentByURL makes qBittorrent download and add the .torrent file at torrentUrl.
+func (c *Client) AddTorrentByURL(ctx context.Context, torrentUrl string, opts AddTorrentOptions) error {
+	return c.AddTorrentURLs(ctx, []string{torrentUrl}, opts)
+}
+
+// AddTorrentByFile uploads and adds the local .torrent file at filePath.
+func (c *Client) AddTorrentByFile(ctx context.Context, filePath string, opts AddTorrentOptions) error {
+	return c.AddTorrentFiles(ctx, []string{filePath}, opts)
+}
+
+// AddTorrentFiles uploads and adds the local .torrent files at filePaths.
+func (c *Client) AddTorrentFiles(ctx context.Context, filePaths []string, opts AddTorrentOptions) error {
+	if len(filePaths) == 0 {
+		return &Error{Message: "no torrent files given"}
+	}
+
+	var contents = make([][]byte, len(filePaths))
+	for i, filePath := range filePaths {
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		contents[i] = content
+	}
+
+	return c.addTorrent(ctx, opts, func(w *multipart.Writer) error {
+		for i, filePath := range filePaths {
+			if err := writeTorrentFile(w, filepath.Base(filePath), bytes.NewReader(contents[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AddTorrentFromReader uploads and adds the .torrent file read from r, name is
+// the file name sent to qBittorrent.
+func (c *Client) AddTorrentFromReader(ctx context.Context, name string, r io.Reader, opts AddTorrentOptions) error {
+	return c.addTorrent(ctx, opts, func(w *multipart.Writer) error {
+		return writeTorrentFile(w, name, r)
+	})
+}
+
+func writeTorrentFile(w *multipart.Writer, name string, r io.Reader) error {
+	part, err := w.CreateFormFile("torrents", name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, r)
+	return err
+}
+
+func (c *Client) addTorrent(ctx context.Context, opts AddTorrentOptions, writeSource func(*multipart.Writer) error) error {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := writeSource(w); err != nil {
+		return err
+	}
+	if err := opts.writeFields(w); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	var path = "/api/v2/torrents/add"
+	resp, err := c.send(ctx, http.MethodPost, c.getUrl(path, nil), w.FormDataContentType(), body.Bytes(), false, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	answer, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if err = statusError(path, resp, answer, nil); err != nil {
+		return err
+	}
+	// qBittorrent answers "Fails." for invalid and duplicate torrents
+	if string(answer) != "Ok." {
+		return fmt.Errorf("%s: %w", path, ErrTorrentAddFailed)
+	}
+	return nil
+}
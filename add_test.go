@@ -0,0 +1,148 @@
+package qbit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readMultipart returns the fields and files of a multipart body by name.
+func readMultipart(t *testing.T, contentType string, body []byte) (fields map[string]string, files map[string]string) {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("got content type %q", contentType)
+	}
+
+	fields, files = map[string]string{}, map[string]string{}
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if part.FileName() != "" {
+			files[part.FileName()] = string(content)
+		} else {
+			fields[part.FormName()] = string(content)
+		}
+	}
+	return
+}
+
+func TestAddTorrentOptionsWriteFields(t *testing.T) {
+	var rootFolder, autoTMM = false, true
+	var opts = AddTorrentOptions{
+		SavePath:               "/downloads/movies",
+		Category:               "movies",
+		Tags:                   []string{"hd", "new"},
+		Paused:                 true,
+		SkipChecking:           true,
+		RootFolder:             &rootFolder,
+		ContentLayout:          ContentLayoutSubfolder,
+		Rename:                 "renamed",
+		UpLimit:                1024,
+		DlLimit:                2048,
+		RatioLimit:             1.5,
+		SeedingTimeLimit:       60,
+		AutoTMM:                &autoTMM,
+		SequentialDownload:     true,
+		FirstLastPiecePriority: true,
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := opts.writeFields(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	fields, files := readMultipart(t, w.FormDataContentType(), body.Bytes())
+	var want = map[string]string{
+		"savepath":           "/downloads/movies",
+		"category":           "movies",
+		"tags":               "hd,new",
+		"paused":             "true",
+		"skip_checking":      "true",
+		"root_folder":        "false",
+		"contentLayout":      "Subfolder",
+		"rename":             "renamed",
+		"upLimit":            "1024",
+		"dlLimit":            "2048",
+		"ratioLimit":         "1.5",
+		"seedingTimeLimit":   "60",
+		"autoTMM":            "true",
+		"sequentialDownload": "true",
+		"firstLastPiecePrio": "true",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("got fields %v\nwant %v", fields, want)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected no files, got %v", files)
+	}
+}
+
+func TestAddTorrentOptionsZeroValuesNotSent(t *testing.T) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := (&AddTorrentOptions{}).writeFields(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if fields, _ := readMultipart(t, w.FormDataContentType(), body.Bytes()); len(fields) != 0 {
+		t.Errorf("expected no fields, got %v", fields)
+	}
+}
+
+func TestAddTorrentFromReader(t *testing.T) {
+	var contentType string
+	var body []byte
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, _ = ioutil.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v2/torrents/add" {
+			t.Errorf("got %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte("Ok."))
+	})
+	c := newTestClient(t, s)
+
+	err := c.AddTorrentFromReader(context.Background(), "file.torrent", strings.NewReader("d4:infoe"), AddTorrentOptions{Category: "movies"})
+	if err != nil {
+		t.Fatalf("AddTorrentFromReader: %v", err)
+	}
+
+	fields, files := readMultipart(t, contentType, body)
+	if !reflect.DeepEqual(files, map[string]string{"file.torrent": "d4:infoe"}) {
+		t.Errorf("got files %v", files)
+	}
+	if !reflect.DeepEqual(fields, map[string]string{"category": "movies"}) {
+		t.Errorf("got fields %v", fields)
+	}
+}
+
+func TestAddTorrentFails(t *testing.T) {
+	c, _ := newRecordingClient(t, "Fails.")
+
+	err := c.AddTorrentByMagnet(context.Background(), "magnet:?xt=urn:btih:aaa", AddTorrentOptions{})
+	if !errors.Is(err, ErrTorrentAddFailed) || !errors.Is(err, ErrActionFailed) {
+		t.Errorf("got %v, want ErrTorrentAddFailed", err)
+	}
+}
@@ -0,0 +1,204 @@
+package qbit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+type BuildInfo struct {
+	Qt         string `json:"qt"`         // Qt version
+	Libtorrent string `json:"libtorrent"` // libtorrent version
+	Boost      string `json:"boost"`      // Boost version
+	Openssl    string `json:"openssl"`    // OpenSSL version
+	Bitness    int    `json:"bitness"`    // Application bitness (e.g. 64-bit)
+}
+
+// GetBuildInfo returns the versions of the libraries qBittorrent was built with.
+func (c *Client) GetBuildInfo(ctx context.Context) (*BuildInfo, error) {
+	var info BuildInfo
+	if err := c.getJson(ctx, "/api/v2/app/buildInfo", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetAppVersion returns the qBittorrent version, e.g. "v4.1.3".
+func (c *Client) GetAppVersion(ctx context.Context) (string, error) {
+	body, err := c.getBody(ctx, "/api/v2/app/version", nil)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// GetDefaultSavePath returns the path torrents are saved to by default.
+func (c *Client) GetDefaultSavePath(ctx context.Context) (string, error) {
+	body, err := c.getBody(ctx, "/api/v2/app/defaultSavePath", nil)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// Shutdown makes qBittorrent exit and drops the session of the client. As
+// qBittorrent goes away right after answering, the connection being closed
+// after the request is sent counts as success. A refused connection does not,
+// as the request never reached qBittorrent.
+func (c *Client) Shutdown(ctx context.Context) error {
+	if err := c.postAction(ctx, "/api/v2/app/shutdown", url.Values{}); err != nil && !connectionDropped(err) {
+		return err
+	}
+	return c.invalidateSession()
+}
+
+// connectionDropped reports whether err is the server closing the connection
+// after the request was sent.
+func connectionDropped(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// ProxyType is the kind of proxy qBittorrent connects through.
+type ProxyType string
+
+//noinspection GoUnusedConst
+const (
+	ProxyNone   ProxyType = "None"   // No proxy is used
+	ProxyHTTP   ProxyType = "HTTP"   // HTTP proxy
+	ProxySOCKS5 ProxyType = "SOCKS5" // SOCKS5 proxy
+	ProxySOCKS4 ProxyType = "SOCKS4" // SOCKS4 proxy
+)
+
+// legacyProxyTypes maps the numbers qBittorrent used before 4.6 to the names it uses since.
+var legacyProxyTypes = map[int]ProxyType{
+	-1: ProxyNone,
+	0:  ProxyNone,
+	1:  ProxyHTTP,
+	2:  ProxySOCKS5,
+	3:  ProxyHTTP,
+	4:  ProxySOCKS5,
+	5:  ProxySOCKS4,
+}
+
+// UnmarshalJSON accepts both the name qBittorrent sends since 4.6 and the number it sent before.
+func (p *ProxyType) UnmarshalJSON(data []byte) error {
+	var number int
+	if err := json.Unmarshal(data, &number); err == nil {
+		proxyType, ok := legacyProxyTypes[number]
+		if !ok {
+			return fmt.Errorf("unknown proxy type %d", number)
+		}
+		*p = proxyType
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	*p = ProxyType(name)
+	return nil
+}
+
+// Preferences holds the commonly used application preferences. Every field is
+// omitted when empty, so SetPreferences only changes the non-zero fields and a
+// preference can not be set to its zero value through it.
+type Preferences struct {
+	// Paths
+	SavePath                  string                 `json:"save_path,omitempty"`                     // Default save path for torrents
+	TempPath                  string                 `json:"temp_path,omitempty"`                     // Path for incomplete torrents
+	TempPathEnabled           bool                   `json:"temp_path_enabled,omitempty"`             // True if the path for incomplete torrents is used
+	ScanDirs                  map[string]interface{} `json:"scan_dirs,omitempty"`                     // Watched folders and where their torrents are downloaded to
+	ExportDir                 string                 `json:"export_dir,omitempty"`                    // Path to copy .torrent files to
+	ExportDirFin              string                 `json:"export_dir_fin,omitempty"`                // Path to copy .torrent files of completed downloads to
+	AutoTmmEnabled            bool                   `json:"auto_tmm_enabled,omitempty"`              // True if Automatic Torrent Management is enabled by default
+	TorrentChangedTmmEnabled  bool                   `json:"torrent_changed_tmm_enabled,omitempty"`   // True if torrents are relocated when their category changes
+	SavePathChangedTmmEnabled bool                   `json:"save_path_changed_tmm_enabled,omitempty"` // True if torrents are relocated when the default save path changes
+	CategoryChangedTmmEnabled bool                   `json:"category_changed_tmm_enabled,omitempty"`  // True if torrents are relocated when their category save path changes
+	IncompleteFilesExt        bool                   `json:"incomplete_files_ext,omitempty"`          // True if ".!qB" is appended to incomplete files
+	PreallocateAll            bool                   `json:"preallocate_all,omitempty"`               // True if disk space is pre-allocated for all files
+
+	// Queueing
+	QueueingEnabled            bool    `json:"queueing_enabled,omitempty"`               // True if torrent queueing is enabled
+	MaxActiveDownloads         int32   `json:"max_active_downloads,omitempty"`           // Maximum number of active simultaneous downloads
+	MaxActiveTorrents          int32   `json:"max_active_torrents,omitempty"`            // Maximum number of active simultaneous downloads and uploads
+	MaxActiveUploads           int32   `json:"max_active_uploads,omitempty"`             // Maximum number of active simultaneous uploads
+	DontCountSlowTorrents      bool    `json:"dont_count_slow_torrents,omitempty"`       // If true torrents w/o any activity are not counted towards the max active limits
+	SlowTorrentDlRateThreshold int32   `json:"slow_torrent_dl_rate_threshold,omitempty"` // Download rate threshold for a torrent to be counted as slow (KiB/s)
+	SlowTorrentUlRateThreshold int32   `json:"slow_torrent_ul_rate_threshold,omitempty"` // Upload rate threshold for a torrent to be counted as slow (KiB/s)
+	SlowTorrentInactiveTimer   int32   `json:"slow_torrent_inactive_timer,omitempty"`    // Seconds a torrent must be slow before it is counted as slow
+	MaxActiveCheckingTorrents  int32   `json:"max_active_checking_torrents,omitempty"`   // Maximum number of torrents checked simultaneously
+	MaxRatioEnabled            bool    `json:"max_ratio_enabled,omitempty"`              // True if share ratio limit is enabled
+	MaxRatio                   float32 `json:"max_ratio,omitempty"`                      // Global share ratio limit
+	MaxSeedingTimeEnabled      bool    `json:"max_seeding_time_enabled,omitempty"`       // True if the seeding time limit is enabled
+	MaxSeedingTime             int32   `json:"max_seeding_time,omitempty"`               // Global seeding time limit (minutes)
+
+	// Speed limits
+	GlobalDlSpeedLimit            int64 `json:"dl_limit,omitempty"`           // Global download speed limit (bytes/s), -1 if unlimited
+	GlobalUpSpeedLimit            int64 `json:"up_limit,omitempty"`           // Global upload speed limit (bytes/s), -1 if unlimited
+	AlternativeGlobalDlSpeedLimit int64 `json:"alt_dl_limit,omitempty"`       // Alternative global download speed limit (bytes/s)
+	AlternativeGlobalUpSpeedLimit int64 `json:"alt_up_limit,omitempty"`       // Alternative global upload speed limit (bytes/s)
+	SchedulerEnabled              bool  `json:"scheduler_enabled,omitempty"`  // True if the alternative limits are applied according to schedule
+	LimitUtpRate                  bool  `json:"limit_utp_rate,omitempty"`     // True if the limits apply to µTP connections
+	LimitTcpOverhead              bool  `json:"limit_tcp_overhead,omitempty"` // True if the limits apply to the transport overhead
+	LimitLanPeers                 bool  `json:"limit_lan_peers,omitempty"`    // True if the limits apply to peers on the LAN
+
+	// Networking
+	ListenPort              int32     `json:"listen_port,omitempty"`               // Port for incoming connections
+	RandomPort              bool      `json:"random_port,omitempty"`               // True if the port is randomly selected
+	Upnp                    bool      `json:"upnp,omitempty"`                      // True if UPnP/NAT-PMP is enabled
+	MaxConnec               int32     `json:"max_connec,omitempty"`                // Maximum global number of simultaneous connections
+	MaxConnecPerTorrent     int32     `json:"max_connec_per_torrent,omitempty"`    // Maximum number of simultaneous connections per torrent
+	MaxUploads              int32     `json:"max_uploads,omitempty"`               // Maximum number of upload slots
+	MaxUploadsPerTorrent    int32     `json:"max_uploads_per_torrent,omitempty"`   // Maximum number of upload slots per torrent
+	Dht                     bool      `json:"dht,omitempty"`                       // True if DHT is enabled
+	Pex                     bool      `json:"pex,omitempty"`                       // True if PeX is enabled
+	Lsd                     bool      `json:"lsd,omitempty"`                       // True if LSD is enabled
+	Encryption              int32     `json:"encryption,omitempty"`                // 0 prefers encryption, 1 forces it on and 2 forces it off
+	AnonymousMode           bool      `json:"anonymous_mode,omitempty"`            // True if anonymous mode is enabled
+	ProxyType               ProxyType `json:"proxy_type,omitempty"`                // Proxy type, ProxyNone if no proxy is used
+	ProxyIp                 string    `json:"proxy_ip,omitempty"`                  // Proxy IP address or domain name
+	ProxyPort               int32     `json:"proxy_port,omitempty"`                // Proxy port
+	ProxyPeerConnections    bool      `json:"proxy_peer_connections,omitempty"`    // True if peer and web seed connections go through the proxy
+	CurrentNetworkInterface string    `json:"current_network_interface,omitempty"` // Network interface used
+	CurrentInterfaceAddress string    `json:"current_interface_address,omitempty"` // IP address of the network interface used
+}
+
+// GetPreferences returns the application preferences.
+func (c *Client) GetPreferences(ctx context.Context) (*Preferences, error) {
+	var prefs Preferences
+	if err := c.getJson(ctx, "/api/v2/app/preferences", nil, &prefs); err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// SetPreferences changes the non-zero preferences of prefs, leaving all other
+// preferences untouched.
+func (c *Client) SetPreferences(ctx context.Context, prefs *Preferences) error {
+	return c.setPreferences(ctx, prefs)
+}
+
+// SetPreferenceValues changes the preferences in values, keyed by their json
+// name, leaving all other preferences untouched. Unlike SetPreferences it can
+// set preferences to their zero value, e.g. {"dht": false}.
+func (c *Client) SetPreferenceValues(ctx context.Context, values map[string]interface{}) error {
+	return c.setPreferences(ctx, values)
+}
+
+// setPreferences sends prefs as json, qBittorrent only applies the keys it receives.
+func (c *Client) setPreferences(ctx context.Context, prefs interface{}) error {
+	encoded, err := json.Marshal(prefs)
+	if err != nil {
+		return err
+	}
+
+	var form = url.Values{}
+	form.Set("json", string(encoded))
+	return c.postAction(ctx, "/api/v2/app/setPreferences", form)
+}
@@ -0,0 +1,121 @@
+package qbit
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestShutdownConnectionClosed(t *testing.T) {
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/app/shutdown" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack: %v", err)
+			return
+		}
+		_ = conn.Close()
+	})
+	c := newTestClient(t, s)
+
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if c.IsLoggedIn() {
+		t.Error("expected the session to be dropped")
+	}
+}
+
+func TestShutdownConnectionRefused(t *testing.T) {
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {})
+	c := newTestClient(t, s, WithMaxRetries(0))
+
+	if _, err := c.GetAppVersion(context.Background()); err != nil {
+		t.Fatalf("GetAppVersion: %v", err)
+	}
+	s.Close()
+
+	if err := c.Shutdown(context.Background()); err == nil {
+		t.Error("expected a refused connection to be reported")
+	}
+}
+
+func TestPreferencesRoundTrip(t *testing.T) {
+	fixture, err := ioutil.ReadFile(filepath.Join("testdata", "preferences_4.6.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sent string
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/app/preferences":
+			_, _ = w.Write(fixture)
+		case "/api/v2/app/setPreferences":
+			sent = r.PostFormValue("json")
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+	c := newTestClient(t, s)
+
+	prefs, err := c.GetPreferences(context.Background())
+	if err != nil {
+		t.Fatalf("GetPreferences: %v", err)
+	}
+	if prefs.ProxyType != ProxySOCKS5 || prefs.ProxyIp != "10.0.0.2" || prefs.ProxyPort != 1080 {
+		t.Errorf("unexpected proxy %q %s:%d", prefs.ProxyType, prefs.ProxyIp, prefs.ProxyPort)
+	}
+	if prefs.SavePath != "/downloads" || prefs.MaxActiveDownloads != 3 || prefs.MaxRatio != 2.5 || prefs.GlobalUpSpeedLimit != 512000 {
+		t.Errorf("unexpected preferences %+v", prefs)
+	}
+
+	if err = c.SetPreferences(context.Background(), prefs); err != nil {
+		t.Fatalf("SetPreferences: %v", err)
+	}
+
+	var original, roundTripped map[string]interface{}
+	if err = json.Unmarshal(fixture, &original); err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal([]byte(sent), &roundTripped); err != nil {
+		t.Fatalf("invalid json sent: %v", err)
+	}
+	if len(roundTripped) == 0 {
+		t.Fatal("no preferences were sent")
+	}
+	for key, value := range roundTripped {
+		if !reflect.DeepEqual(value, original[key]) {
+			t.Errorf("%s: sent %v, got %v", key, value, original[key])
+		}
+	}
+}
+
+func TestProxyTypeLegacyNumbers(t *testing.T) {
+	var tests = map[string]ProxyType{
+		`-1`:       ProxyNone,
+		`2`:        ProxySOCKS5,
+		`5`:        ProxySOCKS4,
+		`"HTTP"`:   ProxyHTTP,
+		`"SOCKS4"`: ProxySOCKS4,
+	}
+	for data, want := range tests {
+		var got ProxyType
+		if err := json.Unmarshal([]byte(data), &got); err != nil {
+			t.Errorf("%s: %v", data, err)
+		} else if got != want {
+			t.Errorf("%s: got %q, want %q", data, got, want)
+		}
+	}
+
+	var got ProxyType
+	if err := json.Unmarshal([]byte(`42`), &got); err == nil {
+		t.Error("expected an unknown proxy type to fail")
+	}
+}
@@ -0,0 +1,86 @@
+package qbit
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling qBittorrent while the circuit
+// breaker is open after too many consecutive failures.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// circuitBreaker stops requests after failThreshold consecutive failures for
+// resetTimeout, after which a single probe request decides whether the
+// circuit closes again. A failThreshold of 0 disables it.
+type circuitBreaker struct {
+	failThreshold int
+	resetTimeout  time.Duration
+
+	mu       sync.Mutex
+	failures int       // Consecutive failures
+	openedAt time.Time // When the circuit opened, zero while closed
+	probing  bool      // True while the probe after resetTimeout is in flight
+}
+
+// allow reports whether a request may be sent.
+func (b *circuitBreaker) allow() bool {
+	if b.failThreshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.probing || time.Since(b.openedAt) < b.resetTimeout {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record registers the outcome of a request allowed by allow.
+func (b *circuitBreaker) record(failed bool) {
+	if b.failThreshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.failThreshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// ignore releases the probe of a request whose outcome says nothing about
+// qBittorrent, without counting it as a success or a failure.
+func (b *circuitBreaker) ignore() {
+	if b.failThreshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// requestFailed reports whether the outcome of a request counts as a failure
+// of qBittorrent: a network error, a timeout of the client or a 5xx answer.
+// Requests ended by the context of the caller are ignored before getting here.
+func requestFailed(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
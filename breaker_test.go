@@ -0,0 +1,78 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowServer answers every request after delay, or once the client gives up.
+func slowServer(t *testing.T, delay time.Duration) *fakeServer {
+	return newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+		}
+	})
+}
+
+func TestCircuitBreakerIgnoresCallerDeadline(t *testing.T) {
+	s := slowServer(t, time.Second)
+	c := newTestClient(t, s, WithMaxRetries(0), WithCircuitBreaker(1, time.Minute))
+	if err := c.loginIfNeeded(context.Background(), s.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err := c.GetAppVersion(ctx)
+		cancel()
+
+		var timeoutErr *TimeoutError
+		if !errors.As(err, &timeoutErr) {
+			t.Fatalf("call %d: got %v, want a TimeoutError", i+1, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetAppVersion(ctx); errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("the deadlines of the caller opened the circuit")
+	}
+}
+
+func TestCircuitBreakerOpensOnClientTimeout(t *testing.T) {
+	s := slowServer(t, time.Second)
+	c := newTestClient(t, s, WithMaxRetries(0), WithCircuitBreaker(2, time.Minute), WithTimeout(10*time.Millisecond))
+	if err := c.loginIfNeeded(context.Background(), s.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetAppVersion(context.Background()); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: got %v, want a timeout", i+1, err)
+		}
+	}
+	if _, err := c.GetAppVersion(context.Background()); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("got %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestCircuitBreakerOpensOnServerErrors(t *testing.T) {
+	var calls int32
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c := newTestClient(t, s, WithMaxRetries(0), WithCircuitBreaker(3, time.Minute))
+
+	for i := 0; i < 5; i++ {
+		_, _ = c.GetAppVersion(context.Background())
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("got %d calls, want 3 before the circuit opened", n)
+	}
+}
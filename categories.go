@@ -0,0 +1,85 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrCategoryExists is returned when adding a category that already exists.
+	ErrCategoryExists = errors.New("category already exists")
+	// ErrCategoryNotFound is returned when using a category that does not exist, it wraps ErrNotFound.
+	ErrCategoryNotFound = fmt.Errorf("category %w", ErrNotFound)
+)
+
+type Category struct {
+	Name     string `json:"name"`     // Category name
+	SavePath string `json:"savePath"` // Save path of the torrents in the category
+}
+
+// GetCategories returns all categories by name.
+func (c *Client) GetCategories(ctx context.Context) (categories map[string]Category, err error) {
+	err = c.getJson(ctx, "/api/v2/torrents/categories", nil, &categories)
+	return
+}
+
+// AddCategory creates a new category. ErrCategoryExists is returned if it already exists.
+func (c *Client) AddCategory(ctx context.Context, name, savePath string) error {
+	return c.postActionErrors(ctx, "/api/v2/torrents/createCategory", categoryForm(name, savePath), statusErrors{
+		http.StatusConflict: ErrCategoryExists,
+	})
+}
+
+// CreateCategory is AddCategory under the name of the qBittorrent endpoint.
+func (c *Client) CreateCategory(ctx context.Context, name, savePath string) error {
+	return c.AddCategory(ctx, name, savePath)
+}
+
+// EditCategory changes the save path of an existing category.
+func (c *Client) EditCategory(ctx context.Context, name, newSavePath string) error {
+	return c.postAction(ctx, "/api/v2/torrents/editCategory", categoryForm(name, newSavePath))
+}
+
+// RemoveCategories removes the named categories. qBittorrent silently ignores
+// unknown categories, so they are looked up first and ErrCategoryNotFound is returned
+// without removing anything if one of them does not exist.
+func (c *Client) RemoveCategories(ctx context.Context, names []string) error {
+	categories, err := c.GetCategories(ctx)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, ok := categories[name]; !ok {
+			return fmt.Errorf("%w: %q", ErrCategoryNotFound, name)
+		}
+	}
+
+	var form = url.Values{}
+	form.Set("categories", strings.Join(names, "\n"))
+	return c.postAction(ctx, "/api/v2/torrents/removeCategories", form)
+}
+
+// SetTorrentCategory moves the torrents with the given hashes into category,
+// an empty category removes the torrents from their category.
+// ErrCategoryNotFound is returned if the category does not exist.
+func (c *Client) SetTorrentCategory(ctx context.Context, hashes []string, category string) error {
+	form, err := hashesForm(hashes)
+	if err != nil {
+		return err
+	}
+	form.Set("category", category)
+	return c.postActionErrors(ctx, "/api/v2/torrents/setCategory", form, statusErrors{
+		http.StatusConflict: ErrCategoryNotFound,
+	})
+}
+
+func categoryForm(name, savePath string) url.Values {
+	var form = url.Values{}
+	form.Set("category", name)
+	form.Set("savePath", savePath)
+	return form
+}
@@ -0,0 +1,64 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestCategoryNamesAreEncoded(t *testing.T) {
+	var tests = []struct {
+		name     string
+		savePath string
+		body     string
+	}{
+		{"movies", "/downloads/movies", "category=movies&savePath=%2Fdownloads%2Fmovies"},
+		{"movies/hd", "/downloads/movies hd", "category=movies%2Fhd&savePath=%2Fdownloads%2Fmovies+hd"},
+		{"tv shows", "", "category=tv+shows&savePath="},
+		{"a&b=c", "", "category=a%26b%3Dc&savePath="},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, rec := newRecordingClient(t, "")
+
+			if err := c.CreateCategory(context.Background(), test.name, test.savePath); err != nil {
+				t.Fatalf("CreateCategory: %v", err)
+			}
+
+			req := rec.only(t)
+			if req.Path != "/api/v2/torrents/createCategory" {
+				t.Errorf("got path %s", req.Path)
+			}
+			if req.Body != test.body {
+				t.Errorf("got body %s, want %s", req.Body, test.body)
+			}
+			if got := req.Form.Get("category"); got != test.name {
+				t.Errorf("qBittorrent would see category %q, want %q", got, test.name)
+			}
+		})
+	}
+}
+
+func TestSetTorrentCategoryEncoded(t *testing.T) {
+	c, rec := newRecordingClient(t, "")
+
+	if err := c.SetTorrentCategory(context.Background(), []string{"aaa"}, "movies/hd 1080p"); err != nil {
+		t.Fatalf("SetTorrentCategory: %v", err)
+	}
+	if body := rec.only(t).Body; body != "category=movies%2Fhd+1080p&hashes=aaa" {
+		t.Errorf("got body %s", body)
+	}
+}
+
+func TestSetTorrentCategoryNotFound(t *testing.T) {
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	c := newTestClient(t, s)
+
+	err := c.SetTorrentCategory(context.Background(), []string{"aaa"}, "unknown")
+	if !errors.Is(err, ErrCategoryNotFound) || !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %v, want ErrCategoryNotFound", err)
+	}
+}
@@ -0,0 +1,106 @@
+package qbit
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// debugBodyLimit is how much of every response body is logged in debug mode.
+const debugBodyLimit = 512
+
+const redacted = "REDACTED"
+
+// sessionCookie matches the session cookie of qBittorrent in Cookie and Set-Cookie headers.
+var sessionCookie = regexp.MustCompile(`(SID=)[^;]*`)
+
+// debugTransport logs every request and response going through it, with the
+// password and the session cookie redacted.
+type debugTransport struct {
+	next   http.RoundTripper
+	logger Logger
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var form string
+	if req.GetBody != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form = redactedForm(req)
+	}
+	t.logger.Info("debug: %s %s headers=%v form=%q",
+		req.Method, redactedUrl(req.URL), redactedHeaders(req.Header, "Cookie"), form)
+
+	var start = time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.logger.Info("debug: %s %s failed after %s: %s", req.Method, redactedUrl(req.URL), time.Since(start), err)
+		return nil, err
+	}
+
+	peek, err := ioutil.ReadAll(io.LimitReader(resp.Body, debugBodyLimit))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(peek), resp.Body), resp.Body}
+
+	t.logger.Info("debug: %s %s answered %s after %s headers=%v body=%q",
+		req.Method, redactedUrl(req.URL), resp.Status, time.Since(start), redactedHeaders(resp.Header, "Set-Cookie"), peek)
+	return resp, nil
+}
+
+// redactedUrl returns u without the password of its userinfo.
+func redactedUrl(u *url.URL) string {
+	if _, ok := u.User.Password(); !ok {
+		return u.String()
+	}
+	var copied = *u
+	copied.User = url.UserPassword(u.User.Username(), redacted)
+	return copied.String()
+}
+
+// redactedHeaders returns a copy of headers with the session cookie in
+// cookieHeader and any credentials redacted.
+func redactedHeaders(headers http.Header, cookieHeader string) http.Header {
+	var copied = make(http.Header, len(headers))
+	for name, values := range headers {
+		copied[name] = append([]string(nil), values...)
+	}
+	for i, value := range copied[cookieHeader] {
+		copied[cookieHeader][i] = sessionCookie.ReplaceAllString(value, "${1}"+redacted)
+	}
+	if _, ok := copied["Authorization"]; ok {
+		copied.Set("Authorization", redacted)
+	}
+	return copied
+}
+
+// redactedForm returns the form posted by req with the password redacted.
+// The body of req is left untouched.
+func redactedForm(req *http.Request) string {
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	form, err := url.ParseQuery(string(content))
+	if err != nil {
+		return ""
+	}
+	if _, ok := form["password"]; ok {
+		form.Set("password", redacted)
+	}
+	return form.Encode()
+}
@@ -0,0 +1,121 @@
+package qbit
+
+import (
+	"context"
+	"github.com/spf13/viper"
+)
+
+// The package level functions below are kept for backward compatibility. They
+// all delegate to a default Client configured from the viper keys "url",
+// "username", "password", "timeout", "debug", "ca_cert", "client_cert",
+// "client_key", "insecure_skip_verify" and "no_auth", read on first use. The
+// keys "stalled_limit", "stalled_sort" and "stalled_reverse" are only used by
+// the deprecated GetStalledDownloads.
+//
+// The functions without a context are bounded only by the client timeout,
+// prefer the Ctx variants to be able to cancel or put a deadline on a call.
+
+// defaultLogger is the Logger of the default client, it can be replaced
+// with SetLogger before or after the default client is created.
+var defaultLogger = newLockedLogger(DefaultLogger{})
+
+// SetLogger replaces the Logger of the package level functions, nil disables logging.
+//noinspection GoUnusedExportedFunction
+func SetLogger(l Logger) {
+	defaultLogger.set(l)
+}
+
+// getDefaultClient returns the default client, or the error it could not be
+// created with. The error is kept, so every later call reports it as well.
+func getDefaultClient() (*Client, error) {
+	defaultClientOnce.Do(func() {
+		var opts = []ClientOption{WithLogger(defaultLogger), WithDebug(viper.GetBool("debug"))}
+		if caCert := viper.GetString("ca_cert"); caCert != "" {
+			opts = append(opts, WithCACert(caCert))
+		}
+		if clientCert := viper.GetString("client_cert"); clientCert != "" {
+			opts = append(opts, WithClientCert(clientCert, viper.GetString("client_key")))
+		}
+		opts = append(opts, WithInsecureSkipVerify(viper.GetBool("insecure_skip_verify")))
+		if viper.GetBool("no_auth") {
+			opts = append(opts, WithNoAuth())
+		}
+		if timeout := viper.GetDuration("timeout"); timeout > 0 {
+			opts = append(opts, WithTimeout(timeout))
+		}
+
+		var stalledReverse = true
+		if viper.IsSet("stalled_reverse") {
+			stalledReverse = viper.GetBool("stalled_reverse")
+		}
+		opts = append(opts, WithStalledQuery(
+			viper.GetInt("stalled_limit"),
+			SortField(viper.GetString("stalled_sort")),
+			stalledReverse,
+		))
+
+		defaultClient, defaultClientErr = New(
+			viper.GetString("url"),
+			viper.GetString("username"),
+			viper.GetString("password"),
+			opts...,
+		)
+	})
+	return defaultClient, defaultClientErr
+}
+
+//noinspection GoUnusedExportedFunction
+func GetStalledDownloads() ([]TorrentInfo, error) {
+	return GetStalledDownloadsCtx(context.Background())
+}
+
+//noinspection GoUnusedExportedFunction
+func GetStalledDownloadsCtx(ctx context.Context) ([]TorrentInfo, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetStalledDownloads(ctx)
+}
+
+//noinspection GoUnusedExportedFunction
+func GetVersion() ([]byte, error) {
+	return GetVersionCtx(context.Background())
+}
+
+//noinspection GoUnusedExportedFunction
+func GetVersionCtx(ctx context.Context) ([]byte, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetVersion(ctx)
+}
+
+//noinspection GoUnusedExportedFunction
+func GetTrackerInfo(torrent *TorrentInfo) (Trackers, error) {
+	return GetTrackerInfoCtx(context.Background(), torrent)
+}
+
+//noinspection GoUnusedExportedFunction
+func GetTrackerInfoCtx(ctx context.Context, torrent *TorrentInfo) (Trackers, error) {
+	c, err := getDefaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetTrackerInfo(ctx, torrent)
+}
+
+//noinspection GoUnusedExportedFunction
+func ForceReannounce(hashes []string) error {
+	return ForceReannounceCtx(context.Background(), hashes)
+}
+
+//noinspection GoUnusedExportedFunction
+func ForceReannounceCtx(ctx context.Context, hashes []string) error {
+	c, err := getDefaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ForceReannounce(ctx, hashes)
+}
@@ -0,0 +1,67 @@
+package qbit
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func resetDefaultClient() {
+	viper.Reset()
+	defaultClient, defaultClientErr, defaultClientOnce = nil, nil, sync.Once{}
+}
+
+func TestDefaultClientErrorIsReturned(t *testing.T) {
+	resetDefaultClient()
+	t.Cleanup(resetDefaultClient)
+
+	viper.Set("url", "https://localhost:8080")
+	viper.Set("ca_cert", filepath.Join("testdata", "missing.pem"))
+
+	for i := 0; i < 2; i++ {
+		if _, err := GetStalledDownloads(); err == nil {
+			t.Fatalf("call %d: expected the missing CA certificate to be reported", i+1)
+		}
+	}
+	if err := ForceReannounce([]string{"hash"}); err == nil {
+		t.Error("expected the missing CA certificate to be reported")
+	}
+}
+
+func TestDefaultClientStalledQuery(t *testing.T) {
+	var tests = []struct {
+		name string
+		keys map[string]interface{}
+		want string
+	}{
+		{"default", nil, "filter=stalled_downloading&reverse=true&sort=added_on"},
+		{"overridden", map[string]interface{}{"stalled_limit": 10, "stalled_sort": "name", "stalled_reverse": false}, "filter=stalled_downloading&limit=10&sort=name"},
+		{"only limit", map[string]interface{}{"stalled_limit": "5"}, "filter=stalled_downloading&limit=5&reverse=true&sort=added_on"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resetDefaultClient()
+			SetLogger(nil)
+			t.Cleanup(func() {
+				resetDefaultClient()
+				SetLogger(DefaultLogger{})
+			})
+
+			var rec = &requestRecorder{answer: "[]"}
+			s := newFakeServer(t, rec.ServeHTTP)
+			viper.Set("url", s.URL)
+			for key, value := range test.keys {
+				viper.Set(key, value)
+			}
+
+			if _, err := GetStalledDownloads(); err != nil {
+				t.Fatalf("GetStalledDownloads: %v", err)
+			}
+			if got := rec.only(t).Query.Encode(); got != test.want {
+				t.Errorf("got query %s, want %s", got, test.want)
+			}
+		})
+	}
+}
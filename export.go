@@ -0,0 +1,36 @@
+package qbit
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+)
+
+// ExportTorrent returns the .torrent file of a torrent. ErrNotFound is
+// returned if there is no torrent with the hash.
+func (c *Client) ExportTorrent(ctx context.Context, hash string) ([]byte, error) {
+	var query = url.Values{}
+	query.Set("hash", hash)
+	return c.getBody(ctx, "/api/v2/torrents/export", query)
+}
+
+// ExportTorrentToFile writes the .torrent file of a torrent to filePath. The
+// file is first written next to it with a .tmp suffix and then renamed, so
+// filePath never contains a partial file.
+func (c *Client) ExportTorrentToFile(ctx context.Context, hash, filePath string) error {
+	content, err := c.ExportTorrent(ctx, hash)
+	if err != nil {
+		return err
+	}
+
+	var tmpPath = filePath + ".tmp"
+	if err = ioutil.WriteFile(tmpPath, content, 0644); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpPath, filePath); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
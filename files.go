@@ -0,0 +1,82 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFilePriority is returned when a FilePriority is not one of the known priorities.
+var ErrInvalidFilePriority = errors.New("invalid file priority")
+
+// FilePriority is the download priority of a file inside a torrent.
+type FilePriority int
+
+//noinspection GoUnusedConst
+const (
+	FilePrioritySkip    FilePriority = 0 // Do not download
+	FilePriorityNormal  FilePriority = 1 // Normal priority
+	FilePriorityHigh    FilePriority = 6 // High priority
+	FilePriorityMaximal FilePriority = 7 // Maximal priority
+	FilePriorityMax                  = FilePriorityMaximal
+)
+
+// Valid reports whether p is a priority accepted by qBittorrent.
+func (p FilePriority) Valid() bool {
+	switch p {
+	case FilePrioritySkip, FilePriorityNormal, FilePriorityHigh, FilePriorityMaximal:
+		return true
+	}
+	return false
+}
+
+type TorrentFile struct {
+	Index        int          `json:"index"`        // File index
+	Name         string       `json:"name"`         // File name (including relative path)
+	Size         int64        `json:"size"`         // File size (bytes)
+	Progress     float32      `json:"progress"`     // File progress (percentage/100)
+	Priority     FilePriority `json:"priority"`     // File priority
+	IsSeed       bool         `json:"is_seed"`      // True if file is seeding/complete
+	PieceRange   []int        `json:"piece_range"`  // The first number is the starting piece index and the second number is the ending piece index (inclusive)
+	Availability float32      `json:"availability"` // Percentage of file pieces currently available (percentage/100)
+}
+
+// GetTorrentFiles returns the files of a torrent.
+func (c *Client) GetTorrentFiles(ctx context.Context, hash string) (files []TorrentFile, err error) {
+	err = c.getJson(ctx, "/api/v2/torrents/files", url.Values{"hash": {hash}}, &files)
+	if err != nil {
+		return
+	}
+
+	// Older versions do not send the index, the file id is then the position in the list
+	for i := range files {
+		if files[i].Index != 0 {
+			return
+		}
+	}
+	for i := range files {
+		files[i].Index = i
+	}
+	return
+}
+
+// SetFilePriority sets the priority of the files with the given ids, as found
+// in TorrentFile.Index, of a torrent.
+func (c *Client) SetFilePriority(ctx context.Context, hash string, fileIds []int, priority FilePriority) error {
+	if !priority.Valid() {
+		return ErrInvalidFilePriority
+	}
+
+	var ids = make([]string, len(fileIds))
+	for i, id := range fileIds {
+		ids[i] = strconv.Itoa(id)
+	}
+
+	var form = url.Values{}
+	form.Set("hash", hash)
+	form.Set("id", strings.Join(ids, "|"))
+	form.Set("priority", strconv.Itoa(int(priority)))
+	return c.postAction(ctx, "/api/v2/torrents/filePrio", form)
+}
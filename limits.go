@@ -0,0 +1,178 @@
+package qbit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Share limit values with a special meaning for SetShareLimits.
+//noinspection GoUnusedConst
+const (
+	ShareLimitGlobal    = -2 // Use the global share limit
+	ShareLimitUnlimited = -1 // No share limit
+)
+
+// Typed share limit values for SetTorrentRatioLimit and SetTorrentSeedingTimeLimit.
+//noinspection GoUnusedConst
+const (
+	RatioGlobalDefault       float32 = ShareLimitGlobal    // Use the global ratio limit
+	RatioUnlimited           float32 = ShareLimitUnlimited // No ratio limit
+	SeedingTimeGlobalDefault int32   = ShareLimitGlobal    // Use the global seeding time limit
+	SeedingTimeUnlimited     int32   = ShareLimitUnlimited // No seeding time limit
+)
+
+// shareLimits are the limits set together by SetShareLimits.
+type shareLimits struct {
+	ratio               float64
+	seedingTime         int
+	inactiveSeedingTime int
+}
+
+// SetTorrentDownloadLimit sets the download limit (bytes/s) of the torrents
+// with the given hashes, use Unlimited to remove the limit.
+func (c *Client) SetTorrentDownloadLimit(ctx context.Context, hashes []string, bytesPerSec int64) error {
+	return c.setTorrentLimit(ctx, "/api/v2/torrents/setDownloadLimit", hashes, bytesPerSec)
+}
+
+// SetTorrentUploadLimit sets the upload limit (bytes/s) of the torrents with
+// the given hashes, use Unlimited to remove the limit.
+func (c *Client) SetTorrentUploadLimit(ctx context.Context, hashes []string, bytesPerSec int64) error {
+	return c.setTorrentLimit(ctx, "/api/v2/torrents/setUploadLimit", hashes, bytesPerSec)
+}
+
+// GetTorrentDownloadLimits returns the download limits (bytes/s) of the
+// torrents with the given hashes, keyed by hash.
+func (c *Client) GetTorrentDownloadLimits(ctx context.Context, hashes []string) (map[string]int64, error) {
+	return c.getTorrentLimits(ctx, "/api/v2/torrents/downloadLimit", hashes)
+}
+
+// GetTorrentUploadLimits returns the upload limits (bytes/s) of the torrents
+// with the given hashes, keyed by hash.
+func (c *Client) GetTorrentUploadLimits(ctx context.Context, hashes []string) (map[string]int64, error) {
+	return c.getTorrentLimits(ctx, "/api/v2/torrents/uploadLimit", hashes)
+}
+
+// SetShareLimits sets the share limits of the torrents with the given hashes.
+// Every limit accepts ShareLimitGlobal and ShareLimitUnlimited, the time
+// limits are in minutes.
+func (c *Client) SetShareLimits(ctx context.Context, hashes []string, ratioLimit float64, seedingTimeLimit, inactiveSeedingTimeLimit int) error {
+	form, err := hashesForm(hashes)
+	if err != nil {
+		return err
+	}
+	form.Set("ratioLimit", strconv.FormatFloat(ratioLimit, 'f', -1, 64))
+	form.Set("seedingTimeLimit", strconv.Itoa(seedingTimeLimit))
+	form.Set("inactiveSeedingTimeLimit", strconv.Itoa(inactiveSeedingTimeLimit))
+	return c.postAction(ctx, "/api/v2/torrents/setShareLimits", form)
+}
+
+// SetTorrentRatioLimit sets the ratio limit of the torrents with the given
+// hashes, keeping their seeding time limits. See updateShareLimits for how the
+// other limits are kept.
+func (c *Client) SetTorrentRatioLimit(ctx context.Context, hashes []string, ratio float32) error {
+	return c.updateShareLimits(ctx, hashes, func(limits *shareLimits) {
+		limits.ratio = widenRatio(ratio)
+	})
+}
+
+// SetTorrentSeedingTimeLimit sets the seeding time limit (minutes) of the
+// torrents with the given hashes, keeping their other share limits. See
+// updateShareLimits for how the other limits are kept.
+func (c *Client) SetTorrentSeedingTimeLimit(ctx context.Context, hashes []string, minutes int32) error {
+	return c.updateShareLimits(ctx, hashes, func(limits *shareLimits) {
+		limits.seedingTime = int(minutes)
+	})
+}
+
+// updateShareLimits changes some of the share limits of the torrents. The
+// endpoint only sets all of them at once, so the current limits are looked up
+// and the torrents sharing the same resulting limits are updated together.
+// Reading and writing are separate requests, so a change to the other limits
+// made in between is overwritten. ErrNotFound naming the unknown hashes is
+// returned without changing anything if qBittorrent does not know all of them.
+func (c *Client) updateShareLimits(ctx context.Context, hashes []string, update func(*shareLimits)) error {
+	if len(hashes) == 0 {
+		return ErrNoHashes
+	}
+
+	var query = TorrentQuery{Hashes: hashes}
+	for _, hash := range hashes {
+		if hash == AllTorrents {
+			query.Hashes = nil
+		}
+	}
+	torrents, err := c.GetTorrents(ctx, query)
+	if err != nil {
+		return err
+	}
+	if len(torrents) == 0 {
+		return ErrNotFound
+	}
+	if missing := missingHashes(query.Hashes, torrents); len(missing) > 0 {
+		return fmt.Errorf("torrents %s: %w", strings.Join(missing, ", "), ErrNotFound)
+	}
+
+	var groups = make(map[shareLimits][]string)
+	var order []shareLimits
+	for _, torrent := range torrents {
+		var limits = shareLimits{
+			ratio:               widenRatio(torrent.RatioLimit),
+			seedingTime:         int(torrent.SeedingTimeLimit),
+			inactiveSeedingTime: int(torrent.InactiveSeedingTimeLimit),
+		}
+		update(&limits)
+		if _, ok := groups[limits]; !ok {
+			order = append(order, limits)
+		}
+		groups[limits] = append(groups[limits], torrent.Hash)
+	}
+
+	for _, limits := range order {
+		err = c.SetShareLimits(ctx, groups[limits], limits.ratio, limits.seedingTime, limits.inactiveSeedingTime)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// missingHashes returns the hashes that are not the hash of any of the torrents.
+func missingHashes(hashes []string, torrents []TorrentInfo) (missing []string) {
+	var found = make(map[string]bool, len(torrents))
+	for _, torrent := range torrents {
+		found[strings.ToLower(torrent.Hash)] = true
+	}
+	for _, hash := range hashes {
+		if !found[strings.ToLower(hash)] {
+			missing = append(missing, hash)
+		}
+	}
+	return
+}
+
+// widenRatio converts a ratio to float64 without float32 noise, so 1.1 is
+// sent as 1.1 and not as 1.100000023841858.
+func widenRatio(ratio float32) float64 {
+	widened, _ := strconv.ParseFloat(strconv.FormatFloat(float64(ratio), 'f', -1, 32), 64)
+	return widened
+}
+
+func (c *Client) setTorrentLimit(ctx context.Context, path string, hashes []string, bytesPerSec int64) error {
+	form, err := hashesForm(hashes)
+	if err != nil {
+		return err
+	}
+	form.Set("limit", strconv.FormatInt(bytesPerSec, 10))
+	return c.postAction(ctx, path, form)
+}
+
+func (c *Client) getTorrentLimits(ctx context.Context, path string, hashes []string) (limits map[string]int64, err error) {
+	form, err := hashesForm(hashes)
+	if err != nil {
+		return
+	}
+	err = c.postJson(ctx, path, form, &limits)
+	return
+}
@@ -0,0 +1,60 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSetTorrentRatioLimitUnknownHash(t *testing.T) {
+	var writes int32
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/torrents/info":
+			_, _ = w.Write([]byte(`[{"hash":"known","ratio_limit":-2,"seeding_time_limit":-2,"inactive_seeding_time_limit":-2}]`))
+		case "/api/v2/torrents/setShareLimits":
+			atomic.AddInt32(&writes, 1)
+		}
+	})
+	c := newTestClient(t, s)
+
+	err := c.SetTorrentRatioLimit(context.Background(), []string{"known", "typo"}, 2)
+	if !errors.Is(err, ErrNotFound) || err == nil || !strings.Contains(err.Error(), "typo") {
+		t.Errorf("got %v, want ErrNotFound naming typo", err)
+	}
+	if n := atomic.LoadInt32(&writes); n != 0 {
+		t.Errorf("got %d writes, want none", n)
+	}
+}
+
+func TestSetTorrentRatioLimitKeepsOtherLimits(t *testing.T) {
+	var forms []string
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/torrents/info":
+			_, _ = w.Write([]byte(`[
+				{"hash":"AAA","ratio_limit":-2,"seeding_time_limit":60,"inactive_seeding_time_limit":-2},
+				{"hash":"bbb","ratio_limit":1,"seeding_time_limit":60,"inactive_seeding_time_limit":-2},
+				{"hash":"ccc","ratio_limit":1,"seeding_time_limit":-1,"inactive_seeding_time_limit":-1}]`))
+		case "/api/v2/torrents/setShareLimits":
+			_ = r.ParseForm()
+			forms = append(forms, r.PostForm.Encode())
+		}
+	})
+	c := newTestClient(t, s)
+
+	if err := c.SetTorrentRatioLimit(context.Background(), []string{"aaa", "bbb", "ccc"}, 1.1); err != nil {
+		t.Fatalf("SetTorrentRatioLimit: %v", err)
+	}
+
+	var want = []string{
+		"hashes=AAA%7Cbbb&inactiveSeedingTimeLimit=-2&ratioLimit=1.1&seedingTimeLimit=60",
+		"hashes=ccc&inactiveSeedingTimeLimit=-1&ratioLimit=1.1&seedingTimeLimit=-1",
+	}
+	if strings.Join(forms, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got forms\n%s\nwant\n%s", strings.Join(forms, "\n"), strings.Join(want, "\n"))
+	}
+}
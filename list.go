@@ -0,0 +1,218 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ErrInvalidFilter is returned when a TorrentFilter is not known by qBittorrent.
+var ErrInvalidFilter = errors.New("invalid torrent filter")
+
+// TorrentFilter filters the torrents returned by GetTorrents on their state.
+type TorrentFilter string
+
+//noinspection GoUnusedConst
+const (
+	FilterAll                TorrentFilter = "all"
+	FilterDownloading        TorrentFilter = "downloading"
+	FilterSeeding            TorrentFilter = "seeding"
+	FilterCompleted          TorrentFilter = "completed"
+	FilterPaused             TorrentFilter = "paused"
+	FilterActive             TorrentFilter = "active"
+	FilterInactive           TorrentFilter = "inactive"
+	FilterResumed            TorrentFilter = "resumed"
+	FilterStalled            TorrentFilter = "stalled"
+	FilterStalledUploading   TorrentFilter = "stalled_uploading"
+	FilterStalledDownloading TorrentFilter = "stalled_downloading"
+	FilterErrored            TorrentFilter = "errored"
+)
+
+// Valid reports whether f is a filter known by qBittorrent.
+func (f TorrentFilter) Valid() bool {
+	switch f {
+	case FilterAll, FilterDownloading, FilterSeeding, FilterCompleted, FilterPaused, FilterActive,
+		FilterInactive, FilterResumed, FilterStalled, FilterStalledUploading, FilterStalledDownloading,
+		FilterErrored:
+		return true
+	}
+	return false
+}
+
+// SortField is a TorrentInfo field the torrents returned by GetTorrents can be sorted by.
+type SortField string
+
+//noinspection GoUnusedConst
+const (
+	SortName              SortField = "name"
+	SortHash              SortField = "hash"
+	SortSize              SortField = "size"
+	SortProgress          SortField = "progress"
+	SortDlspeed           SortField = "dlspeed"
+	SortUpspeed           SortField = "upspeed"
+	SortPriority          SortField = "priority"
+	SortNumSeeds          SortField = "num_seeds"
+	SortNumLeechs         SortField = "num_leechs"
+	SortRatio             SortField = "ratio"
+	SortEta               SortField = "eta"
+	SortState             SortField = "state"
+	SortCategory          SortField = "category"
+	SortTags              SortField = "tags"
+	SortAddedOn           SortField = "added_on"
+	SortCompletionOn      SortField = "completion_on"
+	SortTracker           SortField = "tracker"
+	SortDlLimit           SortField = "dl_limit"
+	SortUpLimit           SortField = "up_limit"
+	SortDownloaded        SortField = "downloaded"
+	SortUploaded          SortField = "uploaded"
+	SortDownloadedSession SortField = "downloaded_session"
+	SortUploadedSession   SortField = "uploaded_session"
+	SortAmountLeft        SortField = "amount_left"
+	SortTimeActive        SortField = "time_active"
+	SortSavePath          SortField = "save_path"
+)
+
+// TorrentQuery selects the torrents returned by GetTorrents. Zero values are
+// not sent, so the zero TorrentQuery lists every torrent.
+type TorrentQuery struct {
+	Filter   TorrentFilter // Only torrents matching this filter
+	Category string        // Only torrents in this category
+	Tag      string        // Only torrents with this tag
+	Sort     SortField     // Sort the torrents by this field
+	Reverse  bool          // Reverse the sorting
+	Limit    int           // Limit the number of torrents returned
+	Offset   int           // Skip this many torrents, negative values count from the end
+	Hashes   []string      // Only torrents with these hashes
+}
+
+// TorrentListOptions is the former name of TorrentQuery.
+type TorrentListOptions = TorrentQuery
+
+func (q *TorrentQuery) query() (url.Values, error) {
+	var query = url.Values{}
+	if q.Filter != "" {
+		if !q.Filter.Valid() {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFilter, q.Filter)
+		}
+		query.Set("filter", string(q.Filter))
+	}
+	if q.Category != "" {
+		query.Set("category", q.Category)
+	}
+	if q.Tag != "" {
+		query.Set("tag", q.Tag)
+	}
+	if q.Sort != "" {
+		query.Set("sort", string(q.Sort))
+	}
+	if q.Reverse {
+		query.Set("reverse", "true")
+	}
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset != 0 {
+		query.Set("offset", strconv.Itoa(q.Offset))
+	}
+	if len(q.Hashes) > 0 {
+		query.Set("hashes", combineHashes(q.Hashes))
+	}
+	return query, nil
+}
+
+// GetTorrents returns the torrents selected by q.
+func (c *Client) GetTorrents(ctx context.Context, q TorrentQuery) (torrents []TorrentInfo, err error) {
+	query, err := q.query()
+	if err != nil {
+		return
+	}
+
+	err = c.getJson(ctx, "/api/v2/torrents/info", query, &torrents)
+	return
+}
+
+// GetTorrentByHash returns the torrent with the given hash, or ErrNotFound.
+func (c *Client) GetTorrentByHash(ctx context.Context, hash string) (*TorrentInfo, error) {
+	torrents, err := c.GetTorrents(ctx, TorrentQuery{Hashes: []string{hash}})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(torrents) == 0 {
+		return nil, fmt.Errorf("torrent %s: %w", hash, ErrNotFound)
+	}
+	if len(torrents) > 1 {
+		c.logger.Warn("Got %d torrents for hash %s, using the first", len(torrents), hash)
+	}
+	return &torrents[0], nil
+}
+
+// GetStalledUploads returns the torrents that are seeding without any connections.
+func (c *Client) GetStalledUploads(ctx context.Context) ([]TorrentInfo, error) {
+	return c.GetTorrents(ctx, TorrentQuery{Filter: FilterStalledUploading})
+}
+
+// GetTorrentsByState returns the torrents in exactly the given state.
+// qBittorrent can only filter on groups of states, so the torrents of the
+// narrowest filter are fetched and filtered on their state.
+func (c *Client) GetTorrentsByState(ctx context.Context, state TorrentState) ([]TorrentInfo, error) {
+	return c.getTorrentsInStates(ctx, TorrentQuery{Filter: stateFilter(state)}, state)
+}
+
+// GetCompletedTorrents returns the torrents that finished downloading and are
+// seeding, uploading or stalled, in category or in every category if it is empty.
+func (c *Client) GetCompletedTorrents(ctx context.Context, category string) ([]TorrentInfo, error) {
+	var query = TorrentQuery{Filter: FilterSeeding, Category: category}
+	return c.getTorrentsInStates(ctx, query, StateUploading, StateStalledUP)
+}
+
+// GetQueuedTorrents returns the torrents waiting in the queue to download or
+// seed, in category or in every category if it is empty.
+func (c *Client) GetQueuedTorrents(ctx context.Context, category string) ([]TorrentInfo, error) {
+	var query = TorrentQuery{Category: category}
+	return c.getTorrentsInStates(ctx, query, StateQueuedDL, StateQueuedUP)
+}
+
+// getTorrentsInStates returns the torrents matching query that are in one of the states.
+func (c *Client) getTorrentsInStates(ctx context.Context, query TorrentQuery, states ...TorrentState) ([]TorrentInfo, error) {
+	torrents, err := c.GetTorrents(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var inState = torrents[:0]
+	for _, torrent := range torrents {
+		for _, state := range states {
+			if torrent.State == state {
+				inState = append(inState, torrent)
+				break
+			}
+		}
+	}
+	return inState, nil
+}
+
+// GetErrorTorrents returns the torrents that stopped because of an error.
+func (c *Client) GetErrorTorrents(ctx context.Context) ([]TorrentInfo, error) {
+	return c.GetTorrentsByState(ctx, StateError)
+}
+
+// GetMissingFileTorrents returns the torrents whose data files are missing.
+func (c *Client) GetMissingFileTorrents(ctx context.Context) ([]TorrentInfo, error) {
+	return c.GetTorrentsByState(ctx, StateMissingFiles)
+}
+
+// stateFilter returns the narrowest filter that includes the torrents in state.
+func stateFilter(state TorrentState) TorrentFilter {
+	switch state {
+	case StateStalledDL:
+		return FilterStalledDownloading
+	case StateStalledUP:
+		return FilterStalledUploading
+	case StateError, StateMissingFiles:
+		return FilterErrored
+	}
+	return FilterAll
+}
@@ -0,0 +1,35 @@
+package qbit
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStalledQuery(t *testing.T) {
+	var tests = []struct {
+		name string
+		opts []ClientOption
+		want string
+	}{
+		{"default", nil, "filter=stalled_downloading&reverse=true&sort=added_on"},
+		{"overridden", []ClientOption{WithStalledQuery(10, SortName, false)}, "filter=stalled_downloading&limit=10&sort=name"},
+		{"empty sort", []ClientOption{WithStalledQuery(5, "", true)}, "filter=stalled_downloading&limit=5&reverse=true&sort=added_on"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, rec := newRecordingClient(t, "[]", test.opts...)
+
+			if _, err := c.GetStalledDownloads(context.Background()); err != nil {
+				t.Fatalf("GetStalledDownloads: %v", err)
+			}
+
+			req := rec.only(t)
+			if req.Path != "/api/v2/torrents/info" {
+				t.Errorf("got path %s", req.Path)
+			}
+			if got := req.Query.Encode(); got != test.want {
+				t.Errorf("got query %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package qbit
+
+import (
+	"log"
+	"sync"
+)
+
+// Logger receives the log messages of a Client. The msg is a format string
+// for args, as with fmt.Printf. Requests are traced at debug level, actions
+// such as reannounces are logged at info level, retries at warn level and
+// failures at error level.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// DefaultLogger writes to the standard log package and drops debug messages.
+// It is the Logger of a Client unless WithLogger is used.
+type DefaultLogger struct{}
+
+func (DefaultLogger) Debug(string, ...interface{}) {}
+
+func (DefaultLogger) Info(msg string, args ...interface{}) {
+	log.Printf(msg, args...)
+}
+
+func (DefaultLogger) Warn(msg string, args ...interface{}) {
+	log.Printf(msg, args...)
+}
+
+func (DefaultLogger) Error(msg string, args ...interface{}) {
+	log.Printf(msg, args...)
+}
+
+// noopLogger drops every message, it is used when WithLogger is given nil.
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...interface{}) {}
+func (noopLogger) Info(string, ...interface{})  {}
+func (noopLogger) Warn(string, ...interface{})  {}
+func (noopLogger) Error(string, ...interface{}) {}
+
+// lockedLogger lets the Logger of a Client be replaced while it is in use.
+type lockedLogger struct {
+	mu     sync.RWMutex
+	logger Logger
+}
+
+func newLockedLogger(l Logger) *lockedLogger {
+	if l == nil {
+		l = noopLogger{}
+	}
+	return &lockedLogger{logger: l}
+}
+
+func (l *lockedLogger) set(logger Logger) {
+	if logger == nil {
+		logger = noopLogger{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger = logger
+}
+
+func (l *lockedLogger) get() Logger {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.logger
+}
+
+func (l *lockedLogger) Debug(msg string, args ...interface{}) { l.get().Debug(msg, args...) }
+func (l *lockedLogger) Info(msg string, args ...interface{})  { l.get().Info(msg, args...) }
+func (l *lockedLogger) Warn(msg string, args ...interface{})  { l.get().Warn(msg, args...) }
+func (l *lockedLogger) Error(msg string, args ...interface{}) { l.get().Error(msg, args...) }
+
+// SetLogger replaces the Logger of the client, nil disables logging. It is
+// safe to call while the client is in use.
+func (c *Client) SetLogger(l Logger) {
+	c.logger.set(l)
+}
@@ -0,0 +1,65 @@
+package qbit
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// LogType is the severity of a LogEntry.
+type LogType int
+
+//noinspection GoUnusedConst
+const (
+	LogTypeNormal   LogType = 1
+	LogTypeInfo     LogType = 2
+	LogTypeWarning  LogType = 4
+	LogTypeCritical LogType = 8
+)
+
+type LogEntry struct {
+	ID        int     `json:"id"`        // ID of the message
+	Message   string  `json:"message"`   // Text of the message
+	Timestamp int64   `json:"timestamp"` // Milliseconds since epoch
+	Type      LogType `json:"type"`      // Type of the message
+}
+
+type PeerLogEntry struct {
+	ID        int    `json:"id"`        // ID of the peer
+	IP        string `json:"ip"`        // IP of the peer
+	Timestamp int64  `json:"timestamp"` // Milliseconds since epoch
+	Blocked   bool   `json:"blocked"`   // Whether or not the peer was blocked
+	Reason    string `json:"reason"`    // Reason of the block
+}
+
+// LogOptions selects the entries returned by GetLog.
+type LogOptions struct {
+	Normal      bool // Include normal messages
+	Info        bool // Include info messages
+	Warning     bool // Include warning messages
+	Critical    bool // Include critical messages
+	LastKnownID int  // Only return entries with a greater ID, -1 returns every entry
+}
+
+// GetLog returns the entries of the main log selected by opts.
+func (c *Client) GetLog(ctx context.Context, opts LogOptions) (entries []LogEntry, err error) {
+	var query = url.Values{}
+	query.Set("normal", strconv.FormatBool(opts.Normal))
+	query.Set("info", strconv.FormatBool(opts.Info))
+	query.Set("warning", strconv.FormatBool(opts.Warning))
+	query.Set("critical", strconv.FormatBool(opts.Critical))
+	query.Set("last_known_id", strconv.Itoa(opts.LastKnownID))
+
+	err = c.getJson(ctx, "/api/v2/log/main", query, &entries)
+	return
+}
+
+// GetPeerLog returns the entries of the peer log with an ID greater than
+// lastKnownID, -1 returns every entry.
+func (c *Client) GetPeerLog(ctx context.Context, lastKnownID int) (entries []PeerLogEntry, err error) {
+	var query = url.Values{}
+	query.Set("last_known_id", strconv.Itoa(lastKnownID))
+
+	err = c.getJson(ctx, "/api/v2/log/peers", query, &entries)
+	return
+}
@@ -0,0 +1,169 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// requestMetrics are the metrics of the requests made by a client.
+type requestMetrics struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+}
+
+func newRequestMetrics(registerer prometheus.Registerer) (*requestMetrics, error) {
+	var duration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "qbit_api_request_duration_seconds",
+			Help: "The duration of the requests to the qBittorrent API",
+		}, []string{"instance", "endpoint"})
+
+	var errorCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "qbit_api_errors_total",
+			Help: "The number of requests to the qBittorrent API answered with a non-2xx status",
+		}, []string{"instance", "endpoint", "status_code"})
+
+	var retries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "qbit_request_retries_total",
+			Help: "The number of requests to the qBittorrent API that were retried",
+		}, []string{"instance", "endpoint"})
+
+	collector, err := register(registerer, duration)
+	if err != nil {
+		return nil, err
+	}
+	duration = collector.(*prometheus.HistogramVec)
+
+	collector, err = register(registerer, errorCount)
+	if err != nil {
+		return nil, err
+	}
+	errorCount = collector.(*prometheus.CounterVec)
+
+	collector, err = register(registerer, retries)
+	if err != nil {
+		return nil, err
+	}
+	retries = collector.(*prometheus.CounterVec)
+
+	return &requestMetrics{duration: duration, errors: errorCount, retries: retries}, nil
+}
+
+// register registers collector, returning the already registered collector
+// instead if another client registered the same metric before.
+func register(registerer prometheus.Registerer, collector prometheus.Collector) (prometheus.Collector, error) {
+	var err = registerer.Register(collector)
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		return alreadyRegistered.ExistingCollector, nil
+	}
+	return collector, err
+}
+
+func (m *requestMetrics) observe(instance string, req *http.Request, resp *http.Response, start time.Time) {
+	var endpoint = apiEndpoint(req)
+	m.duration.WithLabelValues(instance, endpoint).Observe(time.Since(start).Seconds())
+	if resp != nil && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		m.errors.WithLabelValues(instance, endpoint, strconv.Itoa(resp.StatusCode)).Inc()
+	}
+}
+
+// apiEndpoint returns the endpoint of req without the api prefix, e.g. "torrents/info".
+func apiEndpoint(req *http.Request) string {
+	var path = req.URL.Path
+	if i := strings.Index(path, "/api/v2/"); i >= 0 {
+		return path[i+len("/api/v2/"):]
+	}
+	return path
+}
+
+// RefreshMetrics refreshes the global speed, session transfer and torrent
+// count metrics of the client. The torrent counts are not updated by the other API calls, to keep
+// the number of requests predictable call RefreshMetrics on a ticker instead.
+// It is safe to call concurrently.
+func (c *Client) RefreshMetrics(ctx context.Context) error {
+	c.metricsMu.Lock()
+	defer c.metricsMu.Unlock()
+
+	var instance = c.instance()
+	info, err := c.GetTransferInfo(ctx)
+	if err != nil {
+		if errors.Is(err, ErrCircuitOpen) {
+			markMissing(instance)
+		}
+		return err
+	}
+	sessionDownloaded.WithLabelValues(instance).Add(float64(c.downloaded.delta(info.DlInfoData)))
+	sessionUploaded.WithLabelValues(instance).Add(float64(c.uploaded.delta(info.UpInfoData)))
+
+	torrents, err := c.GetTorrents(ctx, TorrentQuery{})
+	if err != nil {
+		return err
+	}
+
+	var downloading, seeding, paused, errored int
+	for _, torrent := range torrents {
+		switch {
+		case torrent.State.IsDownloading():
+			downloading++
+		case torrent.State.IsSeeding():
+			seeding++
+		case torrent.State.IsPaused():
+			paused++
+		case torrent.State.IsErrored():
+			errored++
+		}
+	}
+
+	torrentsTotal.WithLabelValues(instance).Set(float64(len(torrents)))
+	torrentsDownloading.WithLabelValues(instance).Set(float64(downloading))
+	torrentsSeeding.WithLabelValues(instance).Set(float64(seeding))
+	torrentsPaused.WithLabelValues(instance).Set(float64(paused))
+	torrentsError.WithLabelValues(instance).Set(float64(errored))
+	return nil
+}
+
+// markMissing sets the gauges of instance to NaN, so that alerts notice the
+// data is missing instead of seeing the last values forever.
+func markMissing(instance string) {
+	for _, gauge := range []*prometheus.GaugeVec{
+		globalDownloadSpeed, globalUploadSpeed,
+		torrentsTotal, torrentsDownloading, torrentsSeeding, torrentsPaused, torrentsError,
+	} {
+		gauge.WithLabelValues(instance).Set(math.NaN())
+	}
+}
+
+// deltaTracker turns the session totals of qBittorrent, that restart from zero
+// whenever qBittorrent does, into increments of a monotonic counter.
+type deltaTracker struct {
+	last int64
+}
+
+// delta returns how much current has grown since the previous call. A current
+// smaller than before means qBittorrent restarted, so all of it is new.
+func (t *deltaTracker) delta(current int64) int64 {
+	var delta = current - t.last
+	if current < t.last {
+		delta = current
+	}
+	t.last = current
+	return delta
+}
+
+// instance is the value of the instance label of the client metrics, the base
+// url without any credentials.
+func (c *Client) instance() string {
+	var u = *c.baseUrl
+	u.User = nil
+	return u.String()
+}
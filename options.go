@@ -0,0 +1,254 @@
+package qbit
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"github.com/prometheus/client_golang/prometheus"
+	"net/http"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// defaultRetry retries twice, after about 500ms and 1s with full jitter.
+var defaultRetry = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	Jitter:      1,
+}
+
+type clientConfig struct {
+	timeout            time.Duration
+	httpClient         *http.Client
+	transport          http.RoundTripper
+	tlsConfig          *tls.Config
+	caCertPath         string
+	clientCertPath     string
+	clientKeyPath      string
+	insecureSkipVerify bool
+	proxyUrl           string
+	retry              RetryPolicy
+	userAgent          string
+	headers            http.Header
+	noAuth             bool
+	stalled            TorrentQuery
+	registerer         prometheus.Registerer
+	logger             Logger
+	breakerThreshold   int
+	breakerReset       time.Duration
+	debug              bool
+}
+
+func defaultConfig() clientConfig {
+	return clientConfig{
+		registerer: prometheus.DefaultRegisterer,
+		headers:    http.Header{},
+		logger:     DefaultLogger{},
+		retry:      defaultRetry,
+		stalled: TorrentQuery{
+			Filter:  FilterStalledDownloading,
+			Sort:    SortAddedOn,
+			Reverse: true,
+		},
+	}
+}
+
+// ClientOption configures a Client created by New or NewClient.
+type ClientOption func(*clientConfig)
+
+// Option is an alias of ClientOption.
+type Option = ClientOption
+
+// WithTimeout sets the timeout of every request made by the client. Defaults to
+// 30 seconds. Use a context with a deadline to bound a single call further.
+//noinspection GoUnusedExportedFunction
+func WithTimeout(d time.Duration) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.timeout = d
+	}
+}
+
+// WithHTTPClient makes the client use a copy of c for all requests. A cookie
+// jar is added to the copy if c does not have one.
+//noinspection GoUnusedExportedFunction
+func WithHTTPClient(c *http.Client) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.httpClient = c
+	}
+}
+
+// WithTransport makes the client send its requests through rt, e.g. to trace
+// them. The TLS and proxy options only work if rt is an *http.Transport.
+//noinspection GoUnusedExportedFunction
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.transport = rt
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used when talking to qBittorrent over https.
+//noinspection GoUnusedExportedFunction
+func WithTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.tlsConfig = tlsConfig
+	}
+}
+
+// WithCACert makes the client trust the certificates in the PEM file at
+// pemPath, next to the system roots, e.g. for a WebUI certificate from a
+// private CA. New fails if the file has no certificates.
+//noinspection GoUnusedExportedFunction
+func WithCACert(pemPath string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.caCertPath = pemPath
+	}
+}
+
+// WithClientCert makes the client present the certificate at certPath with
+// the key at keyPath, both PEM encoded. New fails if they can not be loaded.
+//noinspection GoUnusedExportedFunction
+func WithClientCert(certPath, keyPath string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.clientCertPath = certPath
+		cfg.clientKeyPath = keyPath
+	}
+}
+
+// WithInsecureSkipVerify disables verifying the certificate of qBittorrent.
+// Only use it for testing, it makes https vulnerable to interception.
+//noinspection GoUnusedExportedFunction
+func WithInsecureSkipVerify(skip bool) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.insecureSkipVerify = skip
+	}
+}
+
+// WithHTTPProxy routes all requests through the proxy at rawUrl, socks5://
+// urls are supported as well. Without it the HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY environment variables are used.
+//noinspection GoUnusedExportedFunction
+func WithHTTPProxy(rawUrl string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.proxyUrl = rawUrl
+	}
+}
+
+// WithMaxRetries sets how many times a failed request is retried, see
+// RetryPolicy for which requests are. Defaults to 2, 0 disables retrying.
+//noinspection GoUnusedExportedFunction
+func WithMaxRetries(n int) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.retry.MaxAttempts = n + 1
+	}
+}
+
+// WithRetry sets the RetryPolicy of the client. By default a request is
+// attempted 3 times, waiting 500ms with full jitter before the first retry.
+//noinspection GoUnusedExportedFunction
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.retry = policy
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+//noinspection GoUnusedExportedFunction
+func WithUserAgent(ua string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.userAgent = ua
+	}
+}
+
+// WithHeader adds a header to every request, including the login, e.g. for
+// the authentication of a reverse proxy in front of qBittorrent.
+//noinspection GoUnusedExportedFunction
+func WithHeader(key, value string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.headers.Add(key, value)
+	}
+}
+
+// WithBasicAuth sends HTTP basic authentication with every request, for a
+// reverse proxy in front of qBittorrent. The qBittorrent login is still done.
+//noinspection GoUnusedExportedFunction
+func WithBasicAuth(user, pass string) ClientOption {
+	return func(cfg *clientConfig) {
+		var credentials = base64.StdEncoding.EncodeToString([]byte(user + ":" + pass))
+		cfg.headers.Set("Authorization", "Basic "+credentials)
+	}
+}
+
+// WithNoAuth skips logging in, for qBittorrent configured to bypass
+// authentication for localhost or a whitelisted subnet.
+//noinspection GoUnusedExportedFunction
+func WithNoAuth() ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.noAuth = true
+	}
+}
+
+// WithStalledQuery sets how GetStalledDownloads sorts and limits the stalled
+// downloads. A limit of 0 means no limit, an empty sort keeps sorting on SortAddedOn.
+// Defaults to every stalled download, most recently added first.
+//noinspection GoUnusedExportedFunction
+func WithStalledQuery(limit int, sort SortField, reverse bool) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.stalled.Limit = limit
+		if sort != "" {
+			cfg.stalled.Sort = sort
+		}
+		cfg.stalled.Reverse = reverse
+	}
+}
+
+// WithRegisterer sets where the request metrics of the client are registered.
+// Defaults to prometheus.DefaultRegisterer.
+//noinspection GoUnusedExportedFunction
+func WithRegisterer(r prometheus.Registerer) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.registerer = r
+	}
+}
+
+// WithLogger sets where the client logs to. Defaults to DefaultLogger, nil
+// disables logging.
+//noinspection GoUnusedExportedFunction
+func WithLogger(l Logger) ClientOption {
+	return func(cfg *clientConfig) {
+		if l == nil {
+			l = noopLogger{}
+		}
+		cfg.logger = l
+	}
+}
+
+// WithRetryBackoff sets the delay before the first retry, it is doubled for
+// every following retry. Defaults to 500ms.
+//noinspection GoUnusedExportedFunction
+func WithRetryBackoff(initial time.Duration) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.retry.BaseDelay = initial
+	}
+}
+
+// WithCircuitBreaker makes the client refuse every request with
+// ErrCircuitOpen for resetTimeout after failThreshold consecutive requests
+// failed with a network error or a 5xx status. After that a single request is
+// let through, and the circuit closes again if it succeeds. Disabled by default.
+//noinspection GoUnusedExportedFunction
+func WithCircuitBreaker(failThreshold int, resetTimeout time.Duration) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.breakerThreshold = failThreshold
+		cfg.breakerReset = resetTimeout
+	}
+}
+
+// WithDebug makes the client log every request and response at info level,
+// including the headers and the start of the body. The password and the
+// session cookie are redacted.
+//noinspection GoUnusedExportedFunction
+func WithDebug(debug bool) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.debug = debug
+	}
+}
@@ -0,0 +1,103 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+var (
+	// ErrBadRequest is returned when qBittorrent rejects the arguments of a request.
+	ErrBadRequest = errors.New("bad request")
+	// ErrInvalidPath is returned when a path or name is rejected as invalid, it wraps ErrBadRequest.
+	ErrInvalidPath = fmt.Errorf("invalid path: %w", ErrBadRequest)
+	// ErrPathNotWritable is returned when qBittorrent cannot write to a save path, it wraps ErrInvalidPath.
+	ErrPathNotWritable = fmt.Errorf("path not writable: %w", ErrInvalidPath)
+	// ErrConflict is returned when the destination already exists or the torrent is being checked.
+	ErrConflict = errors.New("conflict")
+)
+
+// pathErrors maps the statuses of the location and rename endpoints to errors.
+var pathErrors = statusErrors{
+	http.StatusBadRequest: ErrInvalidPath,
+	http.StatusConflict:   ErrConflict,
+}
+
+// locationErrors maps the statuses of the endpoints changing where torrents
+// are saved to errors. Their 403 means qBittorrent cannot write to the path.
+var locationErrors = statusErrors{
+	http.StatusBadRequest: ErrInvalidPath,
+	http.StatusForbidden:  ErrPathNotWritable,
+	http.StatusConflict:   ErrConflict,
+}
+
+// SetLocation moves the data of the torrents to path. ErrPathNotWritable is
+// returned if qBittorrent cannot write to it.
+func (c *Client) SetLocation(ctx context.Context, hashes []string, path string) error {
+	form, err := hashesForm(hashes)
+	if err != nil {
+		return err
+	}
+	form.Set("location", path)
+	return c.postActionErrors(ctx, "/api/v2/torrents/setLocation", form, locationErrors)
+}
+
+// SetTorrentSavePath changes the save path of the torrents. Automatic Torrent
+// Management decides the save path itself, so qBittorrent disables it for
+// the torrents. An empty savePath is refused with ErrInvalidPath without
+// calling qBittorrent, ErrPathNotWritable is returned if qBittorrent cannot
+// write to it.
+func (c *Client) SetTorrentSavePath(ctx context.Context, hashes []string, savePath string) error {
+	if savePath == "" {
+		return ErrInvalidPath
+	}
+	if len(hashes) == 0 {
+		return ErrNoHashes
+	}
+
+	var form = url.Values{}
+	form.Set("id", combineHashes(hashes))
+	form.Set("path", savePath)
+	return c.postActionErrors(ctx, "/api/v2/torrents/setSavePath", form, locationErrors)
+}
+
+// RenameTorrent changes the name of a torrent. An empty newName is refused
+// with ErrInvalidPath without calling qBittorrent.
+func (c *Client) RenameTorrent(ctx context.Context, hash, newName string) error {
+	if newName == "" {
+		return ErrInvalidPath
+	}
+
+	var form = url.Values{}
+	form.Set("hash", hash)
+	form.Set("name", newName)
+	return c.postActionErrors(ctx, "/api/v2/torrents/rename", form, pathErrors)
+}
+
+// RenameFile renames the file at oldPath of a torrent to newPath, both relative
+// to the root of the torrent.
+func (c *Client) RenameFile(ctx context.Context, hash, oldPath, newPath string) error {
+	return c.renamePath(ctx, "/api/v2/torrents/renameFile", hash, oldPath, newPath)
+}
+
+// RenameFolder renames the folder at oldPath of a torrent to newPath, both
+// relative to the root of the torrent.
+func (c *Client) RenameFolder(ctx context.Context, hash, oldPath, newPath string) error {
+	return c.renamePath(ctx, "/api/v2/torrents/renameFolder", hash, oldPath, newPath)
+}
+
+// renamePath renames oldPath of a torrent, an empty newPath is refused
+// with ErrInvalidPath without calling qBittorrent.
+func (c *Client) renamePath(ctx context.Context, path, hash, oldPath, newPath string) error {
+	if newPath == "" {
+		return ErrInvalidPath
+	}
+
+	var form = url.Values{}
+	form.Set("hash", hash)
+	form.Set("oldPath", oldPath)
+	form.Set("newPath", newPath)
+	return c.postActionErrors(ctx, path, form, pathErrors)
+}
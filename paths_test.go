@@ -0,0 +1,49 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestSetTorrentSavePathNotWritable(t *testing.T) {
+	var tests = map[string][]ClientOption{
+		"logged in": nil,
+		"no auth":   {WithNoAuth()},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v2/torrents/setSavePath" {
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+				http.Error(w, "Cannot write to directory", http.StatusForbidden)
+			})
+			c := newTestClient(t, s, opts...)
+
+			err := c.SetTorrentSavePath(context.Background(), []string{"aaa"}, "/read-only")
+			if !errors.Is(err, ErrPathNotWritable) || !errors.Is(err, ErrInvalidPath) {
+				t.Errorf("got %v, want ErrPathNotWritable", err)
+			}
+			var loginErr *LoginError
+			if errors.As(err, &loginErr) {
+				t.Errorf("a path that is not writable was reported as a login error: %v", err)
+			}
+			if n := s.loginCount(); n > 1 {
+				t.Errorf("logged in %d times, want at most 1", n)
+			}
+		})
+	}
+}
+
+func TestSetTorrentSavePathForm(t *testing.T) {
+	c, rec := newRecordingClient(t, "")
+
+	if err := c.SetTorrentSavePath(context.Background(), []string{"aaa", "bbb"}, "/downloads/new"); err != nil {
+		t.Fatalf("SetTorrentSavePath: %v", err)
+	}
+	if body := rec.only(t).Body; body != "id=aaa%7Cbbb&path=%2Fdownloads%2Fnew" {
+		t.Errorf("got body %s", body)
+	}
+}
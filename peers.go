@@ -0,0 +1,129 @@
+package qbit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPeerAddress is returned when a peer is not given as "ip:port".
+	ErrInvalidPeerAddress = errors.New("invalid peer address")
+	// ErrNoPeers is returned when an action needing peers is given none.
+	ErrNoPeers = errors.New("no peers given")
+)
+
+type PeerInfo struct {
+	Client      string  `json:"client"`       // Client of the peer
+	Connection  string  `json:"connection"`   // Connection type, e.g. BT or µTP
+	Country     string  `json:"country"`      // Country of the peer
+	CountryCode string  `json:"country_code"` // Country code of the peer
+	DlSpeed     int64   `json:"dl_speed"`     // Download speed from the peer (bytes/s)
+	UpSpeed     int64   `json:"up_speed"`     // Upload speed to the peer (bytes/s)
+	Downloaded  int64   `json:"downloaded"`   // Data downloaded from the peer (bytes)
+	Uploaded    int64   `json:"uploaded"`     // Data uploaded to the peer (bytes)
+	Files       string  `json:"files"`        // Files the peer is transferring
+	Flags       string  `json:"flags"`        // Peer flags
+	FlagsDesc   string  `json:"flags_desc"`   // Description of the peer flags
+	IP          string  `json:"ip"`           // IP address of the peer
+	Port        int     `json:"port"`         // Port of the peer
+	Progress    float32 `json:"progress"`     // Progress of the peer (percentage/100)
+	Relevance   float32 `json:"relevance"`    // Share of the data the peer has that we do not (percentage/100)
+}
+
+type TorrentPeersResponse struct {
+	Rid          int                 `json:"rid"`           // Response ID to pass to the next call
+	FullUpdate   bool                `json:"full_update"`   // True if Peers contains every peer instead of the changes
+	ShowFlags    bool                `json:"show_flags"`    // True if the country flags should be shown
+	Peers        map[string]PeerInfo `json:"peers"`         // Peers keyed by "ip:port"
+	PeersRemoved []string            `json:"peers_removed"` // Peers that disconnected since rid
+}
+
+// GetTorrentPeers returns the peers of a torrent. A rid of 0 returns all
+// peers, passing the Rid of the previous response only returns what changed
+// since then, in which case the peers only contain the changed fields.
+func (c *Client) GetTorrentPeers(ctx context.Context, hash string, rid int) (*TorrentPeersResponse, error) {
+	var query = url.Values{}
+	query.Set("hash", hash)
+	query.Set("rid", strconv.Itoa(rid))
+
+	var peers TorrentPeersResponse
+	if err := c.getJson(ctx, "/api/v2/sync/torrentPeers", query, &peers); err != nil {
+		return nil, err
+	}
+	return &peers, nil
+}
+
+// BanPeers permanently bans the peers, given as "ip:port", from every torrent.
+// ErrNoPeers or ErrInvalidPeerAddress is returned without calling qBittorrent
+// if there are no peers or any peer is malformed.
+func (c *Client) BanPeers(ctx context.Context, peers []string) error {
+	if len(peers) == 0 {
+		return ErrNoPeers
+	}
+	for _, peer := range peers {
+		if !validPeerAddress(peer) {
+			return fmt.Errorf("%w: %q", ErrInvalidPeerAddress, peer)
+		}
+	}
+
+	var form = url.Values{}
+	form.Set("peers", strings.Join(peers, "|"))
+	return c.postAction(ctx, "/api/v2/transfer/banPeers", form)
+}
+
+type AddPeersResult struct {
+	Added  int `json:"added"`  // Number of peers added to the torrent
+	Failed int `json:"failed"` // Number of peers that could not be added
+}
+
+// AddPeers adds the peers, given as "ip:port" or "[ipv6]:port", to a torrent.
+// ErrNoPeers or ErrInvalidPeerAddress is returned without calling qBittorrent
+// if there are no peers or any peer is malformed.
+func (c *Client) AddPeers(ctx context.Context, hash string, peers []string) (*AddPeersResult, error) {
+	if len(peers) == 0 {
+		return nil, ErrNoPeers
+	}
+	for _, peer := range peers {
+		if !validPeerAddress(peer) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPeerAddress, peer)
+		}
+	}
+
+	var form = url.Values{}
+	form.Set("hashes", hash)
+	form.Set("peers", strings.Join(peers, "|"))
+
+	// qBittorrent answers with the result keyed by torrent hash
+	var results map[string]json.RawMessage
+	if err := c.postJson(ctx, "/api/v2/torrents/addPeers", form, &results); err != nil {
+		return nil, err
+	}
+
+	for resultHash, raw := range results {
+		if strings.EqualFold(resultHash, hash) {
+			var result AddPeersResult
+			if err := json.Unmarshal(raw, &result); err != nil {
+				return nil, err
+			}
+			return &result, nil
+		}
+	}
+	return nil, fmt.Errorf("torrent %s: %w", hash, ErrNotFound)
+}
+
+// validPeerAddress reports whether peer is an ip and port, IPv6 addresses
+// have to be enclosed in brackets.
+func validPeerAddress(peer string) bool {
+	host, port, err := net.SplitHostPort(peer)
+	if err != nil || net.ParseIP(host) == nil {
+		return false
+	}
+	p, err := strconv.Atoi(port)
+	return err == nil && p > 0 && p <= 65535
+}
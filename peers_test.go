@@ -0,0 +1,43 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestBanPeers(t *testing.T) {
+	c, rec := newRecordingClient(t, "")
+
+	if err := c.BanPeers(context.Background(), []string{"10.0.0.1:6881", "[2001:db8::1]:51413"}); err != nil {
+		t.Fatalf("BanPeers: %v", err)
+	}
+	if got := rec.only(t).Form.Get("peers"); got != "10.0.0.1:6881|[2001:db8::1]:51413" {
+		t.Errorf("got peers %q", got)
+	}
+}
+
+func TestBanPeersRefusedWithoutCall(t *testing.T) {
+	var tests = map[string]struct {
+		peers []string
+		want  error
+	}{
+		"nil":       {nil, ErrNoPeers},
+		"empty":     {[]string{}, ErrNoPeers},
+		"no port":   {[]string{"10.0.0.1"}, ErrInvalidPeerAddress},
+		"hostname":  {[]string{"example.org:6881"}, ErrInvalidPeerAddress},
+		"zero port": {[]string{"10.0.0.1:0"}, ErrInvalidPeerAddress},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, rec := newRecordingClient(t, "")
+
+			if err := c.BanPeers(context.Background(), test.peers); !errors.Is(err, test.want) {
+				t.Errorf("got %v, want %v", err, test.want)
+			}
+			if len(rec.requests) != 0 {
+				t.Errorf("expected no requests, got %+v", rec.requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package qbit
+
+import (
+	"context"
+	"net/url"
+)
+
+// PieceState is the download state of a single piece of a torrent.
+type PieceState int
+
+//noinspection GoUnusedConst
+const (
+	PieceNotDownloaded PieceState = 0 // Not downloaded yet
+	PieceDownloading   PieceState = 1 // Now downloading
+	PieceDownloaded    PieceState = 2 // Already downloaded
+)
+
+// GetPieceStates returns the state of every piece of a torrent.
+func (c *Client) GetPieceStates(ctx context.Context, hash string) (states []PieceState, err error) {
+	err = c.getJson(ctx, "/api/v2/torrents/pieceStates", url.Values{"hash": {hash}}, &states)
+	return
+}
+
+// GetPieceHashes returns the hash of every piece of a torrent.
+func (c *Client) GetPieceHashes(ctx context.Context, hash string) (hashes []string, err error) {
+	err = c.getJson(ctx, "/api/v2/torrents/pieceHashes", url.Values{"hash": {hash}}, &hashes)
+	return
+}
+
+// DownloadedRatio returns the share of states that are downloaded, from 0 to 1.
+//noinspection GoUnusedExportedFunction
+func DownloadedRatio(states []PieceState) float64 {
+	if len(states) == 0 {
+		return 0
+	}
+
+	var downloaded int
+	for _, state := range states {
+		if state == PieceDownloaded {
+			downloaded++
+		}
+	}
+	return float64(downloaded) / float64(len(states))
+}
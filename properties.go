@@ -0,0 +1,60 @@
+package qbit
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+type TorrentProperties struct {
+	SavePath               string  `json:"save_path"`                // Torrent save path
+	CreationDate           int64   `json:"creation_date"`            // Torrent creation date (Unix timestamp)
+	PieceSize              int64   `json:"piece_size"`               // Torrent piece size (bytes)
+	Comment                string  `json:"comment"`                  // Torrent comment
+	TotalWasted            int64   `json:"total_wasted"`             // Total data wasted for torrent (bytes)
+	TotalUploaded          int64   `json:"total_uploaded"`           // Total data uploaded for torrent (bytes)
+	TotalUploadedSession   int64   `json:"total_uploaded_session"`   // Total data uploaded this session (bytes)
+	TotalDownloaded        int64   `json:"total_downloaded"`         // Total data downloaded for torrent (bytes)
+	TotalDownloadedSession int64   `json:"total_downloaded_session"` // Total data downloaded this session (bytes)
+	UpLimit                int64   `json:"up_limit"`                 // Torrent upload limit (bytes/s)
+	DlLimit                int64   `json:"dl_limit"`                 // Torrent download limit (bytes/s)
+	TimeElapsed            int64   `json:"time_elapsed"`             // Torrent elapsed time (seconds)
+	SeedingTime            int64   `json:"seeding_time"`             // Torrent elapsed time while complete (seconds)
+	NbConnections          int32   `json:"nb_connections"`           // Torrent connection count
+	NbConnectionsLimit     int32   `json:"nb_connections_limit"`     // Torrent connection count limit
+	ShareRatio             float32 `json:"share_ratio"`              // Torrent share ratio
+	AdditionDate           int64   `json:"addition_date"`            // When this torrent was added (unix timestamp)
+	CompletionDate         int64   `json:"completion_date"`          // Torrent completion date (unix timestamp)
+	CreatedBy              string  `json:"created_by"`               // Torrent creator
+	DlSpeedAvg             int64   `json:"dl_speed_avg"`             // Torrent average download speed (bytes/second)
+	DlSpeed                int64   `json:"dl_speed"`                 // Torrent download speed (bytes/second)
+	Eta                    int64   `json:"eta"`                      // Torrent ETA (seconds)
+	LastSeen               int64   `json:"last_seen"`                // Last seen complete date (unix timestamp)
+	Peers                  int32   `json:"peers"`                    // Number of peers connected to
+	PeersTotal             int32   `json:"peers_total"`              // Number of peers in the swarm
+	PiecesHave             int32   `json:"pieces_have"`              // Number of pieces owned
+	PiecesNum              int32   `json:"pieces_num"`               // Number of pieces of the torrent
+	Reannounce             int64   `json:"reannounce"`               // Number of seconds until the next announce
+	Seeds                  int32   `json:"seeds"`                    // Number of seeds connected to
+	SeedsTotal             int32   `json:"seeds_total"`              // Number of seeds in the swarm
+	TotalSize              int64   `json:"total_size"`               // Torrent total size (bytes)
+	UpSpeedAvg             int64   `json:"up_speed_avg"`             // Torrent average upload speed (bytes/second)
+	UpSpeed                int64   `json:"up_speed"`                 // Torrent upload speed (bytes/second)
+}
+
+// GetTorrentProperties returns the generic properties of a torrent. ErrNotFound
+// is returned if qBittorrent does not know the hash.
+func (c *Client) GetTorrentProperties(ctx context.Context, hash string) (*TorrentProperties, error) {
+	var properties TorrentProperties
+	err := c.getJson(ctx, "/api/v2/torrents/properties", url.Values{"hash": {hash}}, &properties)
+	if err != nil {
+		return nil, err
+	}
+	return &properties, nil
+}
+
+// ReannounceIn returns the time until the next announce, so that forcing a
+// reannounce can be skipped when one is already imminent.
+func (p *TorrentProperties) ReannounceIn() time.Duration {
+	return time.Duration(p.Reannounce) * time.Second
+}
@@ -0,0 +1,67 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetTorrentProperties(t *testing.T) {
+	c, rec := newRecordingClient(t, `{
+		"save_path":"/downloads/","creation_date":1581234567,"piece_size":4194304,"comment":"",
+		"total_wasted":0,"total_uploaded":5368709120,"total_downloaded":2147483648,
+		"up_limit":-1,"dl_limit":-1,"time_elapsed":86400,"seeding_time":43200,
+		"nb_connections":3,"nb_connections_limit":100,"share_ratio":2.5,
+		"addition_date":1581234600,"completion_date":1581240000,"created_by":"mktorrent 1.1",
+		"dl_speed_avg":102400,"dl_speed":0,"eta":8640000,"last_seen":1581300000,
+		"peers":1,"peers_total":10,"pieces_have":512,"pieces_num":512,"reannounce":1200,
+		"seeds":2,"seeds_total":20,"total_size":2147483648,"up_speed_avg":2048,"up_speed":4096}`)
+
+	props, err := c.GetTorrentProperties(context.Background(), "aaa")
+	if err != nil {
+		t.Fatalf("GetTorrentProperties: %v", err)
+	}
+
+	req := rec.only(t)
+	if req.Method != http.MethodGet || req.Path != "/api/v2/torrents/properties" || req.Query.Get("hash") != "aaa" {
+		t.Errorf("got %s %s?%s", req.Method, req.Path, req.Query.Encode())
+	}
+
+	var want = TorrentProperties{
+		SavePath: "/downloads/", CreationDate: 1581234567, PieceSize: 4194304,
+		TotalUploaded: 5368709120, TotalDownloaded: 2147483648,
+		UpLimit: -1, DlLimit: -1, TimeElapsed: 86400, SeedingTime: 43200,
+		NbConnections: 3, NbConnectionsLimit: 100, ShareRatio: 2.5,
+		AdditionDate: 1581234600, CompletionDate: 1581240000, CreatedBy: "mktorrent 1.1",
+		DlSpeedAvg: 102400, Eta: 8640000, LastSeen: 1581300000,
+		Peers: 1, PeersTotal: 10, PiecesHave: 512, PiecesNum: 512, Reannounce: 1200,
+		Seeds: 2, SeedsTotal: 20, TotalSize: 2147483648, UpSpeedAvg: 2048, UpSpeed: 4096,
+	}
+	if *props != want {
+		t.Errorf("got %+v\nwant %+v", *props, want)
+	}
+	if got := props.ReannounceIn(); got != 20*time.Minute {
+		t.Errorf("got reannounce in %s, want 20m", got)
+	}
+}
+
+func TestGetTorrentPropertiesNotFound(t *testing.T) {
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+	c := newTestClient(t, s)
+
+	props, err := c.GetTorrentProperties(context.Background(), "unknown")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %v, want ErrNotFound", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Endpoint != "/api/v2/torrents/properties" {
+		t.Errorf("got %#v, want an APIError for the properties endpoint", err)
+	}
+	if props != nil {
+		t.Errorf("expected no properties, got %+v", props)
+	}
+}
@@ -1,17 +1,20 @@
 package qbit
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	"github.com/spf13/viper"
+	"io"
 	"io/ioutil"
-	"log"
+	"net"
 	"net/http"
-	"net/http/cookiejar"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -22,73 +25,169 @@ var (
 			Help: "The number of forced reannounces made to stalled torrents",
 		})
 
-	client = setupClient()
+	rechecksMade = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "qbit_rechecks_made",
+			Help: "The number of forced rechecks made",
+		})
+
+	reloginsMade = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "qbit_relogins_made",
+			Help: "The number of logins made because the session had expired",
+		})
+
+	globalDownloadSpeed = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "qbit_global_download_speed_bytes",
+			Help: "The global download speed in bytes per second",
+		}, []string{"instance"})
+
+	globalUploadSpeed = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "qbit_global_upload_speed_bytes",
+			Help: "The global upload speed in bytes per second",
+		}, []string{"instance"})
+
+	sessionDownloaded = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "qbit_session_downloaded_bytes_total",
+			Help: "The number of bytes downloaded, summed over qBittorrent restarts",
+		}, []string{"instance"})
+
+	sessionUploaded = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "qbit_session_uploaded_bytes_total",
+			Help: "The number of bytes uploaded, summed over qBittorrent restarts",
+		}, []string{"instance"})
+
+	torrentsTotal = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "qbit_torrents_total",
+			Help: "The number of torrents",
+		}, []string{"instance"})
+
+	torrentsDownloading = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "qbit_torrents_downloading",
+			Help: "The number of torrents that are downloading",
+		}, []string{"instance"})
+
+	torrentsSeeding = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "qbit_torrents_seeding",
+			Help: "The number of torrents that are seeding",
+		}, []string{"instance"})
+
+	torrentsPaused = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "qbit_torrents_paused",
+			Help: "The number of torrents that are paused",
+		}, []string{"instance"})
+
+	torrentsError = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "qbit_torrents_error",
+			Help: "The number of torrents that are errored",
+		}, []string{"instance"})
+
+	defaultClient     *Client
+	defaultClientErr  error
+	defaultClientOnce sync.Once
 )
 
+// Client talks to a single qBittorrent instance and keeps its own session.
+// It is safe for concurrent use, concurrent calls share a single login.
+type Client struct {
+	baseUrl    *url.URL
+	username   string
+	password   string
+	retry      RetryPolicy
+	breaker    *circuitBreaker
+	userAgent  string
+	headers    http.Header
+	noAuth     bool // Never log in, see WithNoAuth
+	stalled    TorrentQuery
+	client     *http.Client
+	jar        *sessionJar
+	loginMu    sync.Mutex // Held while logging in, so only one login happens at a time
+	logger     *lockedLogger
+	metrics    *requestMetrics
+	metricsMu  sync.Mutex
+	downloaded deltaTracker
+	uploaded   deltaTracker
+}
+
 type TorrentInfo struct {
-	AddedOn           int64   `json:"added_on"`           // Time (Unix Epoch) when the torrent was added to the client
-	AmountLeft        int64   `json:"amount_left"`        // Amount of data left to download (bytes)
-	AutoTmm           bool    `json:"auto_tmm"`           // Whether this torrent is managed by Automatic Torrent Management
-	Availability      float32 `json:"availability"`       // Percentage of file pieces currently available
-	Category          string  `json:"category"`           // Category of the torrent
-	Completed         int64   `json:"completed"`          // Amount of transfer data completed (bytes)
-	CompletionOn      int64   `json:"completion_on"`      // Time (Unix Epoch) when the torrent completed
-	DlLimit           int64   `json:"dl_limit"`           // Torrent download speed limit (bytes/s). -1 if ulimited.
-	Dlspeed           int64   `json:"dlspeed"`            // Torrent download speed (bytes/s)
-	Downloaded        int64   `json:"downloaded"`         // Amount of data downloaded
-	DownloadedSession int64   `json:"downloaded_session"` // Amount of data downloaded this session
-	Eta               int32   `json:"eta"`                // Torrent ETA (seconds)
-	FLPiecePrio       bool    `json:"f_l_piece_prio"`     // True if first last piece are prioritized
-	ForceStart        bool    `json:"force_start"`        // True if force start is enabled for this torrent
-	Hash              string  `json:"hash"`               // Torrent hash
-	LastActivity      int64   `json:"last_activity"`      // Last time (Unix Epoch) when a chunk was downloaded/uploaded
-	MagnetUri         string  `json:"magnet_uri"`         // Magnet URI corresponding to this torrent
-	MaxRatio          float32 `json:"max_ratio"`          // Maximum share ratio until torrent is stopped from seeding/uploading
-	MaxSeedingTime    int32   `json:"max_seeding_time"`   // Maximum seeding time (seconds) until torrent is stopped from seeding
-	Name              string  `json:"name"`               // Torrent name
-	NumComplete       int32   `json:"num_complete"`       // Number of seeds in the swarm
-	NumIncomplete     int32   `json:"num_incomplete"`     // Number of leechers in the swarm
-	NumLeechs         int32   `json:"num_leechs"`         // Number of leechers connected to
-	NumSeeds          int32   `json:"num_seeds"`          // Number of seeds connected to
-	Priority          int32   `json:"priority"`           // Torrent priority.Returns -1 if queuing is disabled or torrent is in seed mode
-	Progress          float32 `json:"progress"`           // Torrent progress (percentage/100)
-	Ratio             float32 `json:"ratio"`              // Torrent share ratio.Max ratio value: 9999.
-	RatioLimit        float32 `json:"ratio_limit"`        // TODO (what is different from max_ratio?)
-	SavePath          string  `json:"save_path"`          // Path where this torrent's data is stored
-	SeedingTimeLimit  int32   `json:"seeding_time_limit"` // TODO (what is different from max_seeding_time?)
-	SeenComplete      int64   `json:"seen_complete"`      // Time (Unix Epoch) when this torrent was last seen complete
-	SeqDl             bool    `json:"seq_dl"`             // True if sequential download is enabled
-	Size              int64   `json:"size"`               // Total size (bytes) of files selected for download
-	State             string  `json:"state"`              // Torrent state.See table here below for the possible values
-	SuperSeeding      bool    `json:"super_seeding"`      // True if super seeding is enabled
-	Tags              string  `json:"tags"`               // Comma-concatenated tag list of the torrent
-	TimeActive        int32   `json:"time_active"`        // Total active time (seconds)
-	TotalSize         int64   `json:"total_size"`         // Total size (bytes) of all file in this torrent (including unselected ones)
-	Tracker           string  `json:"tracker"`            // The first tracker with working status.(TODO: what is returned if no tracker is working?)
-	UpLimit           int32   `json:"up_limit"`           // Torrent upload speed limit (bytes/s).-1 if ulimited.
-	Uploaded          int64   `json:"uploaded"`           // Amount of data uploaded
-	UploadedSession   int64   `json:"uploaded_session"`   // Amount of data uploaded this session
-	Upspeed           int32   `json:"upspeed"`            // Torrent upload speed (bytes/s)
+	AddedOn                  int64        `json:"added_on"`                    // Time (Unix Epoch) when the torrent was added to the client
+	AmountLeft               int64        `json:"amount_left"`                 // Amount of data left to download (bytes)
+	AutoTmm                  bool         `json:"auto_tmm"`                    // Whether this torrent is managed by Automatic Torrent Management
+	Availability             float32      `json:"availability"`                // Percentage of file pieces currently available
+	Category                 string       `json:"category"`                    // Category of the torrent
+	Completed                int64        `json:"completed"`                   // Amount of transfer data completed (bytes)
+	CompletionOn             int64        `json:"completion_on"`               // Time (Unix Epoch) when the torrent completed
+	DlLimit                  int64        `json:"dl_limit"`                    // Torrent download speed limit (bytes/s). -1 if unlimited.
+	Dlspeed                  int64        `json:"dlspeed"`                     // Torrent download speed (bytes/s)
+	DownloadPath             string       `json:"download_path"`               // Path where this torrent's incomplete data is stored
+	Downloaded               int64        `json:"downloaded"`                  // Amount of data downloaded
+	DownloadedSession        int64        `json:"downloaded_session"`          // Amount of data downloaded this session
+	Eta                      int64        `json:"eta"`                         // Torrent ETA (seconds)
+	FLPiecePrio              bool         `json:"f_l_piece_prio"`              // True if first last piece are prioritized
+	ForceStart               bool         `json:"force_start"`                 // True if force start is enabled for this torrent
+	Hash                     string       `json:"hash"`                        // Torrent hash
+	InactiveSeedingTimeLimit int64        `json:"inactive_seeding_time_limit"` // Inactive seeding time limit (minutes), -2 means the global limit and -1 no limit
+	InfohashV1               string       `json:"infohash_v1"`                 // Torrent SHA-1 info hash, empty for v2-only torrents
+	InfohashV2               string       `json:"infohash_v2"`                 // Torrent SHA-256 info hash, empty for v1-only torrents
+	LastActivity             int64        `json:"last_activity"`               // Last time (Unix Epoch) when a chunk was downloaded/uploaded
+	MagnetUri                string       `json:"magnet_uri"`                  // Magnet URI corresponding to this torrent
+	MaxRatio                 float32      `json:"max_ratio"`                   // Maximum share ratio until torrent is stopped from seeding/uploading
+	MaxSeedingTime           int64        `json:"max_seeding_time"`            // Maximum seeding time (seconds) until torrent is stopped from seeding
+	Name                     string       `json:"name"`                        // Torrent name
+	NumComplete              int64        `json:"num_complete"`                // Number of seeds in the swarm
+	NumIncomplete            int64        `json:"num_incomplete"`              // Number of leechers in the swarm
+	NumLeechs                int64        `json:"num_leechs"`                  // Number of leechers connected to
+	NumSeeds                 int64        `json:"num_seeds"`                   // Number of seeds connected to
+	Popularity               float32      `json:"popularity"`                  // Ratio per month the torrent has been active
+	Priority                 int64        `json:"priority"`                    // Torrent priority.Returns -1 if queuing is disabled or torrent is in seed mode
+	Private                  bool         `json:"private"`                     // True if the torrent is from a private tracker, always false before WebAPI 2.11
+	Progress                 float32      `json:"progress"`                    // Torrent progress (percentage/100)
+	Ratio                    float32      `json:"ratio"`                       // Torrent share ratio.Max ratio value: 9999.
+	RatioLimit               float32      `json:"ratio_limit"`                 // TODO (what is different from max_ratio?)
+	Reannounce               int64        `json:"reannounce"`                  // Time until the next announce (seconds)
+	SavePath                 string       `json:"save_path"`                   // Path where this torrent's data is stored
+	SeedingTime              int64        `json:"seeding_time"`                // Total time the torrent has been seeding (seconds)
+	SeedingTimeLimit         int64        `json:"seeding_time_limit"`          // TODO (what is different from max_seeding_time?)
+	SeenComplete             int64        `json:"seen_complete"`               // Time (Unix Epoch) when this torrent was last seen complete
+	SeqDl                    bool         `json:"seq_dl"`                      // True if sequential download is enabled
+	Size                     int64        `json:"size"`                        // Total size (bytes) of files selected for download
+	State                    TorrentState `json:"state"`                       // Torrent state
+	SuperSeeding             bool         `json:"super_seeding"`               // True if super seeding is enabled
+	Tags                     string       `json:"tags"`                        // Comma-concatenated tag list of the torrent
+	TimeActive               int64        `json:"time_active"`                 // Total active time (seconds)
+	TotalSize                int64        `json:"total_size"`                  // Total size (bytes) of all file in this torrent (including unselected ones)
+	Tracker                  string       `json:"tracker"`                     // The first tracker with working status.(TODO: what is returned if no tracker is working?)
+	TrackersCount            int64        `json:"trackers_count"`              // Number of trackers of the torrent
+	UpLimit                  int64        `json:"up_limit"`                    // Torrent upload speed limit (bytes/s). -1 if unlimited.
+	Uploaded                 int64        `json:"uploaded"`                    // Amount of data uploaded
+	UploadedSession          int64        `json:"uploaded_session"`            // Amount of data uploaded this session
+	Upspeed                  int64        `json:"upspeed"`                     // Torrent upload speed (bytes/s)
+}
+
+// IsPrivate reports whether the torrent is from a private tracker. Servers
+// older than WebAPI 2.11 do not report it, so their torrents are never private.
+func (t *TorrentInfo) IsPrivate() bool {
+	return t.Private
 }
 
 type TrackerInfo struct {
-	Url           string `json:"url"`            // Tracker url
-	Status        int    `json:"status"`         // Tracker status. See the table below for possible values
-	NumPeers      int    `json:"num_peers"`      // Number of peers for current torrent, as reported by the tracker
-	NumSeeds      int    `json:"num_seeds"`      // Number of seeds for current torrent, asreported by the tracker
-	NumLeeches    int    `json:"num_leeches"`    // Number of leeches for current torrent, as reported by the tracker
-	NumDownloaded int    `json:"num_downloaded"` // Number of completed downlods for current torrent, as reported by the tracker
-	Msg           string `json:"msg"`            // tracker message (there is no way of knowing what this message is - it's up to tracker admins)
-}
-
-//noinspection GoUnusedConst
-const (
-	TrackerDisabled     = 0 // Tracker is disabled (used for DHT, PeX, and LSD)
-	TrackerNotContacted = 1 // Tracker has not been contacted yet
-	TrackerWorking      = 2 // Tracker has been contacted and is working
-	TrackerUpdating     = 3 // Tracker is updating
-	TrackerNotWorking   = 4 // Tracker has been contacted, but it is not working (or doesn't send proper replies)
-)
+	Url           string        `json:"url"`            // Tracker url
+	Status        TrackerStatus `json:"status"`         // Tracker status
+	NumPeers      int           `json:"num_peers"`      // Number of peers for current torrent, as reported by the tracker
+	NumSeeds      int           `json:"num_seeds"`      // Number of seeds for current torrent, asreported by the tracker
+	NumLeeches    int           `json:"num_leeches"`    // Number of leeches for current torrent, as reported by the tracker
+	NumDownloaded int           `json:"num_downloaded"` // Number of completed downlods for current torrent, as reported by the tracker
+	Msg           string        `json:"msg"`            // tracker message (there is no way of knowing what this message is - it's up to tracker admins)
+}
 
 type LoginError struct {
 	Cause string
@@ -98,6 +197,44 @@ func (e *LoginError) Error() string {
 	return e.Cause
 }
 
+// BannedError is returned when qBittorrent has banned the IP after too many
+// failed login attempts. Callers should back off until the ban has expired.
+type BannedError struct {
+	Cause string
+}
+
+func (e *BannedError) Error() string {
+	return e.Cause
+}
+
+// TimeoutError is returned when a request did not complete in time, either
+// because of the client timeout or because the context deadline passed.
+type TimeoutError struct {
+	Op  string
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return e.Op + ": timed out: " + e.Err.Error()
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+var (
+	// ErrActionFailed is returned when qBittorrent answers an action with "Fails.".
+	ErrActionFailed = errors.New("qBittorrent failed to perform the action")
+	// ErrNoHashes is returned when an action is called without any torrent hashes.
+	ErrNoHashes = errors.New("no torrent hashes given")
+	// ErrNotFound is returned when qBittorrent does not know the requested torrent or item.
+	ErrNotFound = errors.New("not found")
+)
+
 type Error struct {
 	Message string
 }
@@ -106,150 +243,618 @@ func (e *Error) Error() string {
 	return e.Message
 }
 
-func getUrl(parts ...string) string {
-	return viper.GetString("url") + strings.Join(parts, "")
+// APIError is returned when qBittorrent answers with a non-ok status. Err is
+// set to a more specific error, like ErrNotFound, when the status has a known
+// meaning for the endpoint, so errors.Is works with those errors.
+type APIError struct {
+	StatusCode int    // HTTP status code of the answer
+	Body       string // Body of the answer
+	Endpoint   string // Path of the endpoint, e.g. "/api/v2/torrents/info"
+	Err        error  // Meaning of the status for the endpoint, if known
+}
+
+func (e *APIError) Error() string {
+	if e.Err != nil {
+		return e.Endpoint + ": " + e.Err.Error()
+	}
+	return fmt.Sprintf("%s failed: %d %s", e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// IsForbidden reports whether err is qBittorrent answering 403 Forbidden.
+//noinspection GoUnusedExportedFunction
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether err is qBittorrent answering 404 Not Found, or
+// not knowing the requested item in some other way.
+//noinspection GoUnusedExportedFunction
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is qBittorrent answering 409 Conflict.
+//noinspection GoUnusedExportedFunction
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
 }
 
-func setupClient() http.Client {
-	jar, err := cookiejar.New(nil)
+// New creates a Client for the qBittorrent instance at baseUrl. Every Client
+// owns its own cookie jar, so sessions of different clients never interfere.
+//noinspection GoUnusedExportedFunction
+func New(baseUrl, username, password string, opts ...ClientOption) (*Client, error) {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	parsedUrl, err := url.Parse(baseUrl)
 	if err != nil {
-		log.Panic(err)
+		return nil, fmt.Errorf("invalid base url %q: %w", baseUrl, err)
 	}
 
-	var client = http.Client{
-		Timeout: 1 * time.Second,
-		Jar:     jar,
+	c := &Client{
+		baseUrl:   parsedUrl,
+		username:  username,
+		password:  password,
+		retry:     cfg.retry,
+		breaker:   &circuitBreaker{failThreshold: cfg.breakerThreshold, resetTimeout: cfg.breakerReset},
+		userAgent: cfg.userAgent,
+		headers:   cfg.headers,
+		noAuth:    cfg.noAuth,
+		stalled:   cfg.stalled,
+		logger:    newLockedLogger(cfg.logger),
+	}
+	if err = c.setupClient(&cfg); err != nil {
+		return nil, err
+	}
+	if c.metrics, err = newRequestMetrics(cfg.registerer); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewClient is like New but panics if the client cannot be created.
+//noinspection GoUnusedExportedFunction
+func NewClient(baseUrl, username, password string, opts ...ClientOption) *Client {
+	c, err := New(baseUrl, username, password, opts...)
+	if err != nil {
+		panic(err)
 	}
-	return client
+	return c
 }
 
-func needLogin(urlToCall string) bool {
+// getUrl joins path to the path of the base url, so qBittorrent can be served
+// from a sub path of a reverse proxy, and adds the encoded query.
+func (c *Client) getUrl(path string, query url.Values) string {
+	var u = *c.baseUrl
+	u.Path = strings.TrimRight(u.Path, "/") + "/" + strings.TrimLeft(path, "/")
+	u.RawPath = ""
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func (c *Client) setupClient(cfg *clientConfig) error {
+	var client http.Client
+	if cfg.httpClient != nil {
+		client = *cfg.httpClient
+	} else {
+		client.Timeout = defaultTimeout
+	}
+
+	// The timeout is a hard upper bound for every request, a context passed to
+	// the API calls can only make a request end sooner.
+	if cfg.timeout > 0 {
+		client.Timeout = cfg.timeout
+	}
+
+	jar, err := newSessionJar(client.Jar)
+	if err != nil {
+		return err
+	}
+	client.Jar = jar
+	c.jar = jar
+
+	// Without a transport http.DefaultTransport is used, which honours the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+	if cfg.transport != nil {
+		client.Transport = cfg.transport
+	}
+
+	if cfg.customTLS() || cfg.proxyUrl != "" {
+		transport, err := newTransport(client.Transport, cfg)
+		if err != nil {
+			return err
+		}
+		client.Transport = transport
+	}
+
+	if cfg.debug {
+		var next = client.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		client.Transport = &debugTransport{next: next, logger: c.logger}
+	}
+
+	c.client = &client
+	return nil
+}
+
+func newTransport(base http.RoundTripper, cfg *clientConfig) (*http.Transport, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	baseTransport, ok := base.(*http.Transport)
+	if !ok {
+		return nil, &Error{Message: "TLS and proxy options require the http client to use an *http.Transport"}
+	}
+
+	transport := baseTransport.Clone()
+	if cfg.customTLS() {
+		tlsConfig, err := cfg.buildTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	if cfg.proxyUrl != "" {
+		proxyUrl, err := url.Parse(cfg.proxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url %q: %w", cfg.proxyUrl, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+	return transport, nil
+}
+
+func (c *Client) needLogin(urlToCall string) (bool, error) {
+	if c.noAuth {
+		return false, nil
+	}
+
 	parsedUrl, err := url.Parse(urlToCall)
 	if err != nil {
-		log.Panic(err)
+		return false, err
 	}
 
-	cookies := client.Jar.Cookies(parsedUrl)
-	return len(cookies) == 0
+	cookies := c.client.Jar.Cookies(parsedUrl)
+	return len(cookies) == 0, nil
 }
 
-func login() (err error) {
+func (c *Client) login(ctx context.Context) (err error) {
 	var values = url.Values{}
-	values.Set("username", viper.GetString("username"))
-	values.Set("password", viper.GetString("password"))
+	values.Set("username", c.username)
+	values.Set("password", c.password)
 
-	var loginUrl = getUrl("/api/v2/auth/login")
-	req, err := http.NewRequest(http.MethodPost, loginUrl, strings.NewReader(values.Encode()))
+	var loginUrl = c.getUrl("/api/v2/auth/login", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, loginUrl, strings.NewReader(values.Encode()))
 	if err != nil {
 		return
 	}
-	req.Header.Add("Referer", viper.GetString("url"))
+	req.Header.Add("Referer", c.baseUrl.String())
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := client.Do(req)
+	resp, err := c.doRequest(req, true)
 	if err != nil {
 		return
 	}
 	defer resp.Body.Close()
 
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return
+	}
+
+	// qBittorrent answers 200 OK with "Fails." on wrong credentials, only "Ok." means success
+	var message = strings.TrimSpace(string(body))
+	if strings.Contains(message, "banned") {
+		return &BannedError{Cause: message}
+	}
 	if resp.StatusCode != http.StatusOK {
 		return &LoginError{Cause: "Got non-ok status code on login: " + resp.Status}
 	}
+	if message != "Ok." {
+		return &LoginError{Cause: "Login was rejected: " + message}
+	}
 
-	log.Printf("%s was successfully logged in", viper.GetString("username"))
+	c.logger.Info("%s was successfully logged in", c.username)
 	return nil
 }
 
-func loginIfNeeded(url string) {
-	if needLogin(url) {
-		err := login()
-		if err != nil {
-			log.Panic(err)
+// loginIfNeeded logs in if there is no session yet. Concurrent callers wait
+// for the first one to log in instead of all logging in.
+func (c *Client) loginIfNeeded(ctx context.Context, url string) error {
+	need, err := c.needLogin(url)
+	if err != nil || !need {
+		return err
+	}
+
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+	if need, err = c.needLogin(url); err != nil || !need {
+		return err
+	}
+	return c.login(ctx)
+}
+
+// invalidateSession drops the session cookie so that the next call logs in again.
+func (c *Client) invalidateSession() error {
+	return c.jar.reset()
+}
+
+// relogin replaces the session that was current at generation with a new one.
+// If another caller already replaced it, that session is used instead.
+func (c *Client) relogin(ctx context.Context, urlToCall string, generation int) error {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+
+	if c.jar.currentGeneration() != generation {
+		need, err := c.needLogin(urlToCall)
+		if err != nil || !need {
+			return err
 		}
+		return c.login(ctx)
+	}
+
+	if err := c.invalidateSession(); err != nil {
+		return err
 	}
+	reloginsMade.Inc()
+	return c.login(ctx)
 }
 
-//noinspection GoUnusedExportedFunction
-func GetStalledDownloads() (downloads []TorrentInfo, err error) {
-	stalledUrl := getUrl("/api/v2/torrents/info?filter=stalled_downloading&limit=10&sort=added_on&reverse=true")
-	loginIfNeeded(stalledUrl)
+// Logout ends the session of the client, as qBittorrent limits the number of
+// sessions. The client can still be used afterwards, the next call logs in again.
+func (c *Client) Logout(ctx context.Context) error {
+	if c.noAuth {
+		return nil
+	}
+
+	var logoutUrl = c.getUrl("/api/v2/auth/logout", nil)
+	need, err := c.needLogin(logoutUrl)
+	if err != nil || need {
+		return err
+	}
 
-	resp, err := client.Get(stalledUrl)
+	resp, err := c.sendOnce(ctx, http.MethodPost, logoutUrl, "application/x-www-form-urlencoded", []byte{}, false)
 	if err != nil {
-		return
+		return err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	// Forbidden means the session already expired, which is as good as logged out
+	if resp.StatusCode != http.StatusForbidden {
+		if err = statusError("/api/v2/auth/logout", resp, body, nil); err != nil {
+			return err
+		}
+	}
+	return c.invalidateSession()
+}
+
+// IsLoggedIn reports whether the client has a session cookie. It does not
+// call qBittorrent, so the session may have expired on the server.
+func (c *Client) IsLoggedIn() bool {
+	need, err := c.needLogin(c.getUrl("/api/v2/", nil))
+	return err == nil && !need
+}
+
+// Close ends the session of the client with Logout, bounded by the client
+// timeout. It makes the client an io.Closer, so it can be closed with defer.
+func (c *Client) Close() error {
+	return c.Logout(context.Background())
+}
+
+func (c *Client) get(ctx context.Context, urlToCall string) (*http.Response, error) {
+	return c.send(ctx, http.MethodGet, urlToCall, "", nil, true, nil)
+}
+
+func (c *Client) post(ctx context.Context, urlToCall string, form url.Values) (*http.Response, error) {
+	return c.send(ctx, http.MethodPost, urlToCall, "application/x-www-form-urlencoded", []byte(form.Encode()), false, nil)
+}
+
+// postAction posts form to an endpoint that performs an action and only
+// answers with a status, and maybe "Fails.", without any data.
+func (c *Client) postAction(ctx context.Context, path string, form url.Values) error {
+	return c.postActionErrors(ctx, path, form, nil)
+}
+
+// postActionErrors is postAction for endpoints that document what their non-ok statuses mean.
+func (c *Client) postActionErrors(ctx context.Context, path string, form url.Values, errs statusErrors) error {
+	resp, err := c.send(ctx, http.MethodPost, c.getUrl(path, nil), "application/x-www-form-urlencoded", []byte(form.Encode()), false, errs)
+	if err != nil {
+		return err
+	}
+	return checkActionResponse(path, resp, errs)
+}
+
+// getJson performs a GET request to path and decodes the json answer into v.
+func (c *Client) getJson(ctx context.Context, path string, query url.Values, v interface{}) error {
+	body, err := c.getBody(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// getBody performs a GET request to path and returns the body of the answer.
+func (c *Client) getBody(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	resp, err := c.get(ctx, c.getUrl(path, query))
+	if err != nil {
+		return nil, err
+	}
+	return readBody(path, resp)
+}
+
+// postJson posts form to path and decodes the json answer into v.
+func (c *Client) postJson(ctx context.Context, path string, form url.Values, v interface{}) error {
+	resp, err := c.post(ctx, c.getUrl(path, nil), form)
+	if err != nil {
+		return err
 	}
+
+	body, err := readBody(path, resp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// readBody reads and closes the body of resp, failing on non-ok statuses.
+func readBody(path string, resp *http.Response) ([]byte, error) {
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		err = &Error{Message: "Failed to get downloads: " + resp.Status}
-		return
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = statusError(path, resp, body, nil); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// statusErrors maps the non-ok statuses an endpoint documents to errors.
+type statusErrors map[int]error
+
+// statusError returns the error matching a non-ok status of resp, looking in
+// errs before falling back to the generic errors.
+func statusError(path string, resp *http.Response, body []byte, errs statusErrors) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
 	}
 
+	var apiErr = &APIError{StatusCode: resp.StatusCode, Body: string(body), Endpoint: path}
+	if err, ok := errs[resp.StatusCode]; ok {
+		apiErr.Err = err
+	} else if resp.StatusCode == http.StatusNotFound {
+		apiErr.Err = ErrNotFound
+	}
+	return apiErr
+}
+
+func checkActionResponse(path string, resp *http.Response, errs statusErrors) error {
+	defer resp.Body.Close()
+
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return
+		return err
 	}
 
-	err = json.Unmarshal(body, &downloads)
-	return
+	if err = statusError(path, resp, body, errs); err != nil {
+		return err
+	}
+	if string(body) == "Fails." {
+		return fmt.Errorf("%s: %w", path, ErrActionFailed)
+	}
+	return nil
 }
 
-//noinspection GoUnusedExportedFunction
-func GetVersion() (version []byte, err error) {
-	versionUrl := getUrl("/api/v2/app/version")
-	loginIfNeeded(versionUrl)
+// send performs an authenticated request with the given body. qBittorrent
+// answers 403 Forbidden or "Unauthorized." once the session has expired, in
+// which case the client logs in again and retries the request once. Only
+// idempotent requests are retried on transient failures, see RetryPolicy.
+// Statuses in errs have a meaning of their own for the endpoint, so they are
+// never taken for an expired session.
+func (c *Client) send(ctx context.Context, method, urlToCall, contentType string, body []byte, idempotent bool, errs statusErrors) (*http.Response, error) {
+	if err := c.loginIfNeeded(ctx, urlToCall); err != nil {
+		return nil, err
+	}
 
-	resp, err := client.Get(versionUrl)
+	var generation = c.jar.currentGeneration()
+	resp, err := c.sendOnce(ctx, method, urlToCall, contentType, body, idempotent)
 	if err != nil {
-		return
+		return nil, err
+	}
+	if _, ok := errs[resp.StatusCode]; ok {
+		return resp, nil
+	}
+	expired, err := sessionExpired(resp)
+	if err != nil || !expired {
+		return resp, err
+	}
+	if c.noAuth {
+		return nil, &LoginError{Cause: "qBittorrent requires authentication, configure a username and password instead of no auth"}
 	}
-	defer resp.Body.Close()
 
-	version, err = ioutil.ReadAll(resp.Body)
-	return
+	if err = c.relogin(ctx, urlToCall, generation); err != nil {
+		return nil, fmt.Errorf("session expired and logging in again failed: %w", err)
+	}
+
+	resp, err = c.sendOnce(ctx, method, urlToCall, contentType, body, idempotent)
+	if err != nil {
+		return nil, fmt.Errorf("retry after logging in again failed: %w", err)
+	}
+	if expired, err = sessionExpired(resp); err != nil {
+		return nil, err
+	} else if expired {
+		return nil, &LoginError{Cause: "Still unauthorized after logging in again: " + resp.Status}
+	}
+	return resp, nil
 }
 
-//noinspection GoUnusedExportedFunction
-func GetTrackerInfo(torrent *TorrentInfo) (trackerInfo []TrackerInfo, err error) {
-	var trackerInfoUrl = getUrl("/api/v2/torrents/trackers?hash=", torrent.Hash)
-	loginIfNeeded(trackerInfoUrl)
+// sessionExpired reports whether resp is qBittorrent rejecting the session.
+// The body of resp is consumed but replaced, so that it can still be read by the caller.
+func sessionExpired(resp *http.Response) (bool, error) {
+	if resp.StatusCode == http.StatusForbidden {
+		resp.Body.Close()
+		return true, nil
+	}
 
-	resp, err := client.Get(trackerInfoUrl)
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
 	if err != nil {
-		return
+		return false, err
 	}
-	defer resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return string(body) == "Unauthorized.", nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		err = &Error{
-			fmt.Sprintf("Cannot find torrent with hash %s - %s", torrent.Hash, resp.Status),
+func (c *Client) sendOnce(ctx context.Context, method, urlToCall, contentType string, body []byte, idempotent bool) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, urlToCall, reader)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Add("Content-Type", contentType)
+	}
+	return c.doRequest(req, idempotent)
+}
+
+// doRequest sends req, retrying it according to the RetryPolicy if it is
+// idempotent and unless the circuit breaker is open, and makes sure a cancelled or expired context is reported as
+// such instead of as a generic network error. The duration and status of
+// every request is recorded.
+func (c *Client) doRequest(req *http.Request, idempotent bool) (resp *http.Response, err error) {
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	for key, values := range c.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+
+	op := req.Method + " " + req.URL.Path
+	if !c.breaker.allow() {
+		return nil, fmt.Errorf("%s: %w", op, ErrCircuitOpen)
+	}
+
+	var start = time.Now()
+	defer func() {
+		if req.Context().Err() != nil {
+			// The caller cancelled or put a deadline on the request, which says nothing about qBittorrent
+			c.breaker.ignore()
+		} else {
+			c.breaker.record(requestFailed(resp, err))
+		}
+		c.metrics.observe(c.instance(), req, resp, start)
+		if err != nil {
+			c.logger.Debug("%s failed after %s: %s", op, time.Since(start), err)
+		} else {
+			c.logger.Debug("%s answered %s after %s", op, resp.Status, time.Since(start))
+		}
+	}()
+
+	for attempt := 1; ; attempt++ {
+		resp, err = c.client.Do(req)
+		if err != nil {
+			if ctxErr := req.Context().Err(); ctxErr != nil {
+				return nil, contextError(op, ctxErr)
+			}
+		}
+
+		var rewindable = req.Body == nil || req.GetBody != nil
+		if !idempotent || !rewindable || attempt >= c.retry.MaxAttempts || !retryable(resp, err) {
+			break
+		}
+
+		if err != nil {
+			c.logger.Warn("Retrying %s after attempt %d failed: %s", op, attempt, err)
+		} else {
+			c.logger.Warn("Retrying %s after attempt %d got %s", op, attempt, resp.Status)
+			discard(resp)
+		}
+		c.metrics.retries.WithLabelValues(c.instance(), apiEndpoint(req)).Inc()
+
+		if ctxErr := sleep(req.Context(), c.retry.delay(attempt)); ctxErr != nil {
+			return nil, contextError(op, ctxErr)
+		}
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
 		}
-		return
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			return nil, &TimeoutError{Op: op, Err: err}
+		}
+		return nil, err
 	}
+	return resp, nil
+}
 
-	err = json.Unmarshal(body, &trackerInfo)
+// contextError reports a request ended by its context, an expired deadline is a TimeoutError.
+func contextError(op string, ctxErr error) error {
+	if ctxErr == context.DeadlineExceeded {
+		return &TimeoutError{Op: op, Err: ctxErr}
+	}
+	return fmt.Errorf("%s: %w", op, ctxErr)
+}
+
+// GetStalledDownloads returns the stalled downloads, using the query set by WithStalledQuery.
+//
+// Deprecated: use GetTorrents with FilterStalledDownloading and the sort and
+// limit wanted. GetTorrentsByState(ctx, StateStalledDL) does not apply the
+// query set by WithStalledQuery or the stalled_* viper keys.
+func (c *Client) GetStalledDownloads(ctx context.Context) ([]TorrentInfo, error) {
+	return c.GetTorrents(ctx, c.stalled)
+}
+
+func (c *Client) GetVersion(ctx context.Context) ([]byte, error) {
+	return c.getBody(ctx, "/api/v2/app/version", nil)
+}
+
+func (c *Client) GetTrackerInfo(ctx context.Context, torrent *TorrentInfo) (trackerInfo Trackers, err error) {
+	err = c.getJson(ctx, "/api/v2/torrents/trackers", url.Values{"hash": {torrent.Hash}}, &trackerInfo)
 	return
 }
 
-//noinspection GoUnusedExportedFunction
-func ForceReannounce(hashes *[]string) {
-	var announceUrl = getUrl("/api/v2/torrents/reannounce?hashes=", combineHashes(hashes))
-	resp, err := client.Get(announceUrl)
-	if err != nil {
-		log.Printf("Failed to reannounce %v: %s", hashes, err)
-		return
+func (c *Client) ForceReannounce(ctx context.Context, hashes []string) error {
+	// Action endpoints only accept POST on newer qBittorrent versions
+	if err := c.postHashes(ctx, "/api/v2/torrents/reannounce", hashes); err != nil {
+		return err
 	}
-	defer resp.Body.Close()
 
 	reannouncesMade.Inc()
-	log.Printf("Successfully reannounced %v", hashes)
+	c.logger.Info("Successfully reannounced %v", hashes)
+	return nil
+}
+
+// ForceReannounceAll reannounces every torrent.
+func (c *Client) ForceReannounceAll(ctx context.Context) error {
+	return c.ForceReannounce(ctx, []string{AllTorrents})
 }
 
-func combineHashes(hashes *[]string) string {
-	return strings.Join(*hashes, "|")
+func combineHashes(hashes []string) string {
+	return strings.Join(hashes, "|")
 }
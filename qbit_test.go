@@ -0,0 +1,371 @@
+package qbit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// fakeServer stands in for qBittorrent. It accepts every login and hands all
+// other requests to the handler of the test.
+type fakeServer struct {
+	*httptest.Server
+	logins int32 // Number of logins, accessed atomically
+}
+
+func newFakeServer(t *testing.T, handler http.HandlerFunc) *fakeServer {
+	s := &fakeServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/auth/login" {
+			atomic.AddInt32(&s.logins, 1)
+			http.SetCookie(w, &http.Cookie{Name: "SID", Value: "session", Path: "/"})
+			_, _ = w.Write([]byte("Ok."))
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeServer) loginCount() int {
+	return int(atomic.LoadInt32(&s.logins))
+}
+
+// recordedRequest is a request received by a requestRecorder.
+type recordedRequest struct {
+	Method      string     // HTTP method
+	Path        string     // Path without the query
+	Query       url.Values // Query parameters
+	Form        url.Values // Posted url encoded form, nil for other bodies
+	Body        string     // Raw body
+	ContentType string     // Content-Type header
+}
+
+// requestRecorder is a fakeServer handler that keeps every request and answers
+// them all with the same body.
+type requestRecorder struct {
+	mu       sync.Mutex
+	answer   string
+	requests []recordedRequest
+}
+
+func (rec *requestRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	var request = recordedRequest{
+		Method:      r.Method,
+		Path:        r.URL.Path,
+		Query:       r.URL.Query(),
+		ContentType: r.Header.Get("Content-Type"),
+		Body:        string(body),
+	}
+	if strings.HasPrefix(request.ContentType, "application/x-www-form-urlencoded") {
+		request.Form, _ = url.ParseQuery(request.Body)
+	}
+
+	rec.mu.Lock()
+	rec.requests = append(rec.requests, request)
+	rec.mu.Unlock()
+	_, _ = w.Write([]byte(rec.answer))
+}
+
+// only returns the single request received, failing the test if there was not exactly one.
+func (rec *requestRecorder) only(t *testing.T) recordedRequest {
+	t.Helper()
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.requests) != 1 {
+		t.Fatalf("got %d requests, want 1: %+v", len(rec.requests), rec.requests)
+	}
+	return rec.requests[0]
+}
+
+// newRecordingClient returns a client of a fakeServer that records every request.
+func newRecordingClient(t *testing.T, answer string, opts ...ClientOption) (*Client, *requestRecorder) {
+	var rec = &requestRecorder{answer: answer}
+	return newTestClient(t, newFakeServer(t, rec.ServeHTTP), opts...), rec
+}
+
+// newTestClient returns a client of s that keeps its metrics to itself, does
+// not log and retries without noticeable delay.
+func newTestClient(t *testing.T, s *fakeServer, opts ...ClientOption) *Client {
+	opts = append([]ClientOption{
+		WithRegisterer(prometheus.NewRegistry()),
+		WithLogger(nil),
+		WithRetryBackoff(time.Millisecond),
+	}, opts...)
+
+	c, err := New(s.URL, "admin", "adminadmin", opts...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func TestConcurrentCallsLogInOnce(t *testing.T) {
+	var expired int32 = 1
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("v4.6.0"))
+	})
+	s.Config.Handler = sessionChecker(s.Config.Handler, &expired)
+	c := newTestClient(t, s)
+
+	callConcurrently(t, 50, func() error {
+		_, err := c.GetAppVersion(context.Background())
+		return err
+	})
+	if n := s.loginCount(); n != 1 {
+		t.Errorf("logged in %d times, want 1", n)
+	}
+
+	// Every caller now sees the session expire, still only one of them logs in again
+	atomic.StoreInt32(&expired, 1)
+	callConcurrently(t, 50, func() error {
+		_, err := c.GetAppVersion(context.Background())
+		return err
+	})
+	if n := s.loginCount(); n != 2 {
+		t.Errorf("logged in %d times, want 2", n)
+	}
+}
+
+// sessionChecker answers 403 to every request while expired is set, until the
+// next login clears it.
+func sessionChecker(next http.Handler, expired *int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/auth/login" {
+			next.ServeHTTP(w, r)
+			atomic.StoreInt32(expired, 0)
+			return
+		}
+		if atomic.LoadInt32(expired) == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// callConcurrently runs call from n goroutines at once and fails on the first error.
+func callConcurrently(t *testing.T, n int, call func() error) {
+	var start = make(chan struct{})
+	var errs = make(chan error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs <- call()
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestExpiredSessionLogsInAgain(t *testing.T) {
+	var tests = map[string]func(w http.ResponseWriter){
+		"403": func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusForbidden)
+		},
+		"Unauthorized body": func(w http.ResponseWriter) {
+			_, _ = w.Write([]byte("Unauthorized."))
+		},
+	}
+	for name, expire := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 2 {
+					expire(w)
+					return
+				}
+				_, _ = w.Write([]byte("v4.6.0"))
+			})
+			c := newTestClient(t, s)
+
+			for i := 0; i < 2; i++ {
+				version, err := c.GetAppVersion(context.Background())
+				if err != nil {
+					t.Fatalf("call %d: %v", i+1, err)
+				}
+				if version != "v4.6.0" {
+					t.Errorf("call %d: got version %q", i+1, version)
+				}
+			}
+			if n := s.loginCount(); n != 2 {
+				t.Errorf("logged in %d times, want 2", n)
+			}
+			if n := atomic.LoadInt32(&calls); n != 3 {
+				t.Errorf("got %d calls, want 3", n)
+			}
+		})
+	}
+}
+
+func TestStillUnauthorizedAfterLogin(t *testing.T) {
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	c := newTestClient(t, s)
+
+	_, err := c.GetAppVersion(context.Background())
+	var loginErr *LoginError
+	if !errors.As(err, &loginErr) {
+		t.Fatalf("got %v, want a LoginError", err)
+	}
+	if n := s.loginCount(); n != 2 {
+		t.Errorf("logged in %d times, want 2", n)
+	}
+}
+
+func TestForceReannounce(t *testing.T) {
+	c, rec := newRecordingClient(t, "")
+
+	if err := c.ForceReannounce(context.Background(), []string{"aaa", "bbb"}); err != nil {
+		t.Fatalf("ForceReannounce: %v", err)
+	}
+
+	req := rec.only(t)
+	if req.Method != http.MethodPost || req.Path != "/api/v2/torrents/reannounce" {
+		t.Errorf("got %s %s, want POST /api/v2/torrents/reannounce", req.Method, req.Path)
+	}
+	if req.ContentType != "application/x-www-form-urlencoded" {
+		t.Errorf("got content type %q", req.ContentType)
+	}
+	if got := req.Form.Get("hashes"); got != "aaa|bbb" {
+		t.Errorf("got hashes %q, want aaa|bbb", got)
+	}
+	if len(req.Query) != 0 {
+		t.Errorf("expected no query, got %v", req.Query)
+	}
+}
+
+func TestForceReannounceWithoutHashes(t *testing.T) {
+	c, rec := newRecordingClient(t, "")
+
+	if err := c.ForceReannounce(context.Background(), nil); !errors.Is(err, ErrNoHashes) {
+		t.Errorf("got %v, want ErrNoHashes", err)
+	}
+	if len(rec.requests) != 0 {
+		t.Errorf("expected no requests, got %+v", rec.requests)
+	}
+}
+
+func TestTorrentInfoExtremeValues(t *testing.T) {
+	fixture, err := ioutil.ReadFile(filepath.Join("testdata", "torrents_info_extreme.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var torrents []TorrentInfo
+	if err = json.Unmarshal(fixture, &torrents); err != nil {
+		t.Fatalf("decoding extreme values failed: %v", err)
+	}
+	if len(torrents) != 1 {
+		t.Fatalf("got %d torrents, want 1", len(torrents))
+	}
+
+	var torrent = torrents[0]
+	var checks = map[string][2]int64{
+		"AddedOn":                  {torrent.AddedOn, 4102444800},
+		"AmountLeft":               {torrent.AmountLeft, 9007199254740993},
+		"CompletionOn":             {torrent.CompletionOn, -1},
+		"Dlspeed":                  {torrent.Dlspeed, 12500000000},
+		"Downloaded":               {torrent.Downloaded, math.MaxInt64},
+		"Eta":                      {torrent.Eta, 8640000},
+		"InactiveSeedingTimeLimit": {torrent.InactiveSeedingTimeLimit, -2},
+		"MaxSeedingTime":           {torrent.MaxSeedingTime, 5256000},
+		"NumComplete":              {torrent.NumComplete, 2147483648},
+		"NumIncomplete":            {torrent.NumIncomplete, 4294967295},
+		"Priority":                 {torrent.Priority, -1},
+		"Reannounce":               {torrent.Reannounce, 4294967296},
+		"SeedingTime":              {torrent.SeedingTime, 9999999999},
+		"SeedingTimeLimit":         {torrent.SeedingTimeLimit, -2},
+		"Size":                     {torrent.Size, math.MaxInt64},
+		"TimeActive":               {torrent.TimeActive, 9999999999},
+		"UpLimit":                  {torrent.UpLimit, -1},
+		"Uploaded":                 {torrent.Uploaded, math.MaxInt64},
+		"UploadedSession":          {torrent.UploadedSession, 4294967296},
+		"Upspeed":                  {torrent.Upspeed, 3000000000},
+	}
+	for name, check := range checks {
+		if check[0] != check[1] {
+			t.Errorf("%s: got %d, want %d", name, check[0], check[1])
+		}
+	}
+	if torrent.ETADuration() != InfiniteETA {
+		t.Errorf("got ETA %s, want InfiniteETA", torrent.ETADuration())
+	}
+}
+
+func TestTorrentInfo5Fields(t *testing.T) {
+	fixture, err := ioutil.ReadFile(filepath.Join("testdata", "torrents_info_5.0.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := newRecordingClient(t, string(fixture))
+
+	torrents, err := c.GetTorrents(context.Background(), TorrentQuery{})
+	if err != nil {
+		t.Fatalf("GetTorrents: %v", err)
+	}
+	if len(torrents) != 2 {
+		t.Fatalf("got %d torrents, want 2", len(torrents))
+	}
+
+	public, private := torrents[0], torrents[1]
+	if public.IsPrivate() || !private.IsPrivate() {
+		t.Errorf("got private %t and %t, want false and true", public.IsPrivate(), private.IsPrivate())
+	}
+	if public.State != StateStoppedUP || !public.State.IsPaused() {
+		t.Errorf("got state %q, want a paused stoppedUP", public.State)
+	}
+	if public.InfohashV1 != public.Hash || public.InfohashV2 != "" {
+		t.Errorf("got info hashes %q and %q", public.InfohashV1, public.InfohashV2)
+	}
+	if len(private.InfohashV2) != 64 {
+		t.Errorf("got info hash v2 %q", private.InfohashV2)
+	}
+	if public.TrackersCount != 1 || private.TrackersCount != 2 {
+		t.Errorf("got trackers count %d and %d", public.TrackersCount, private.TrackersCount)
+	}
+	if private.DownloadPath != "/downloads/incomplete" || private.AmountLeft != 1073741824 {
+		t.Errorf("got download path %q and amount left %d", private.DownloadPath, private.AmountLeft)
+	}
+	if public.InactiveSeedingTimeLimit != -2 || private.InactiveSeedingTimeLimit != -1 {
+		t.Errorf("got inactive seeding time limits %d and %d", public.InactiveSeedingTimeLimit, private.InactiveSeedingTimeLimit)
+	}
+	if public.SeedingTime != 86210 || public.Reannounce != 1123 || public.Popularity != 1.3218 {
+		t.Errorf("got seeding time %d, reannounce %d and popularity %g", public.SeedingTime, public.Reannounce, public.Popularity)
+	}
+}
+
+func TestTorrentInfoOlderServer(t *testing.T) {
+	var torrent TorrentInfo
+	if err := json.Unmarshal([]byte(`{"hash":"aaa","name":"old","state":"pausedUP","eta":8640000}`), &torrent); err != nil {
+		t.Fatal(err)
+	}
+	if torrent.IsPrivate() || torrent.InfohashV1 != "" || torrent.TrackersCount != 0 {
+		t.Errorf("expected the missing fields to stay zero, got %+v", torrent)
+	}
+}
@@ -0,0 +1,65 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrQueueingDisabled is returned when changing queue positions while torrent queueing is disabled.
+var ErrQueueingDisabled = errors.New("torrent queueing is disabled")
+
+// ErrQueuingDisabled is an alternative spelling of ErrQueueingDisabled.
+var ErrQueuingDisabled = ErrQueueingDisabled
+
+// TopPriority moves the torrents to the top of the queue.
+func (c *Client) TopPriority(ctx context.Context, hashes []string) error {
+	return c.postQueue(ctx, "/api/v2/torrents/topPrio", hashes)
+}
+
+// BottomPriority moves the torrents to the bottom of the queue.
+func (c *Client) BottomPriority(ctx context.Context, hashes []string) error {
+	return c.postQueue(ctx, "/api/v2/torrents/bottomPrio", hashes)
+}
+
+// IncreasePriority moves the torrents one step up in the queue.
+func (c *Client) IncreasePriority(ctx context.Context, hashes []string) error {
+	return c.postQueue(ctx, "/api/v2/torrents/increasePrio", hashes)
+}
+
+// DecreasePriority moves the torrents one step down in the queue.
+func (c *Client) DecreasePriority(ctx context.Context, hashes []string) error {
+	return c.postQueue(ctx, "/api/v2/torrents/decreasePrio", hashes)
+}
+
+// IncreaseTorrentPriority is the same as IncreasePriority.
+func (c *Client) IncreaseTorrentPriority(ctx context.Context, hashes []string) error {
+	return c.IncreasePriority(ctx, hashes)
+}
+
+// DecreaseTorrentPriority is the same as DecreasePriority.
+func (c *Client) DecreaseTorrentPriority(ctx context.Context, hashes []string) error {
+	return c.DecreasePriority(ctx, hashes)
+}
+
+// MoveTorrentsToTopOfQueue is the same as TopPriority.
+func (c *Client) MoveTorrentsToTopOfQueue(ctx context.Context, hashes []string) error {
+	return c.TopPriority(ctx, hashes)
+}
+
+// MoveTorrentsToBottomOfQueue is the same as BottomPriority.
+func (c *Client) MoveTorrentsToBottomOfQueue(ctx context.Context, hashes []string) error {
+	return c.BottomPriority(ctx, hashes)
+}
+
+// postQueue posts the hashes to one of the queue endpoints, mapping the
+// conflict returned when queueing is disabled to ErrQueueingDisabled.
+func (c *Client) postQueue(ctx context.Context, path string, hashes []string) error {
+	form, err := hashesForm(hashes)
+	if err != nil {
+		return err
+	}
+	return c.postActionErrors(ctx, path, form, statusErrors{
+		http.StatusConflict: ErrQueueingDisabled,
+	})
+}
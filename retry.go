@@ -0,0 +1,81 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy decides how often and how fast failed requests are retried.
+// Only reads and logins are retried, as they are safe to repeat, and
+// only when they fail with a network error, a timeout, a 429 or a 502, 503 or
+// 504 answer from a reverse proxy in front of qBittorrent. Other 4xx answers,
+// including failed logins, are never retried.
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts including the first one, 1 or less disables retrying
+	BaseDelay   time.Duration // Delay before the first retry, doubled for every following retry
+	MaxDelay    time.Duration // Upper bound of the delay, 0 means no bound
+	Jitter      float64       // Fraction of the delay that is randomized, from 0 to 1
+}
+
+// delay returns how long to wait before the given retry, starting at 1.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	var delay = p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 && delay > 0 {
+		var jitter = time.Duration(p.Jitter * float64(delay))
+		delay = delay - jitter + time.Duration(rand.Int63n(int64(jitter)+1))
+	}
+	return delay
+}
+
+// retryable reports whether the outcome of a request is worth retrying.
+func retryable(resp *http.Response, err error) bool {
+	if err == nil {
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// discard reads and closes the body of a response that is not used.
+func discard(resp *http.Response) {
+	if resp != nil {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
+
+// sleep waits for d, or until ctx is done in which case its error is returned.
+func sleep(ctx context.Context, d time.Duration) error {
+	var timer = time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,60 @@
+package qbit
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+)
+
+// sessionJar is the cookie jar of a Client. The session can be dropped by
+// swapping the jar it wraps, so the jar of the http.Client itself never
+// changes while requests are using it.
+type sessionJar struct {
+	mu         sync.RWMutex
+	jar        http.CookieJar
+	generation int // Incremented every time the session is dropped
+}
+
+func newSessionJar(jar http.CookieJar) (*sessionJar, error) {
+	if jar == nil {
+		var err error
+		if jar, err = cookiejar.New(nil); err != nil {
+			return nil, err
+		}
+	}
+	return &sessionJar{jar: jar}, nil
+}
+
+func (j *sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	j.jar.SetCookies(u, cookies)
+}
+
+func (j *sessionJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.jar.Cookies(u)
+}
+
+// reset drops every cookie by replacing the wrapped jar with an empty one.
+func (j *sessionJar) reset() error {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.jar = jar
+	j.generation++
+	return nil
+}
+
+// currentGeneration returns how many times the session has been dropped.
+func (j *sessionJar) currentGeneration() int {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.generation
+}
@@ -0,0 +1,71 @@
+package qbit
+
+// TorrentState is the state of a torrent as reported in TorrentInfo.State.
+type TorrentState string
+
+//noinspection GoUnusedConst
+const (
+	StateError              TorrentState = "error"              // Some error occurred, applies to paused torrents
+	StateMissingFiles       TorrentState = "missingFiles"       // Torrent data files is missing
+	StateUploading          TorrentState = "uploading"          // Torrent is being seeded and data is being transferred
+	StatePausedUP           TorrentState = "pausedUP"           // Torrent is paused and has finished downloading
+	StateStoppedUP          TorrentState = "stoppedUP"          // Same as pausedUP, named so since qBittorrent 5.0
+	StateQueuedUP           TorrentState = "queuedUP"           // Queuing is enabled and torrent is queued for upload
+	StateStalledUP          TorrentState = "stalledUP"          // Torrent is being seeded, but no connection were made
+	StateCheckingUP         TorrentState = "checkingUP"         // Torrent has finished downloading and is being checked
+	StateForcedUP           TorrentState = "forcedUP"           // Torrent is forced to uploading and ignore queue limit
+	StateAllocating         TorrentState = "allocating"         // Torrent is allocating disk space for download
+	StateDownloading        TorrentState = "downloading"        // Torrent is being downloaded and data is being transferred
+	StateMetaDL             TorrentState = "metaDL"             // Torrent has just started downloading and is fetching metadata
+	StatePausedDL           TorrentState = "pausedDL"           // Torrent is paused and has NOT finished downloading
+	StateStoppedDL          TorrentState = "stoppedDL"          // Same as pausedDL, named so since qBittorrent 5.0
+	StateQueuedDL           TorrentState = "queuedDL"           // Queuing is enabled and torrent is queued for download
+	StateStalledDL          TorrentState = "stalledDL"          // Torrent is being downloaded, but no connection were made
+	StateCheckingDL         TorrentState = "checkingDL"         // Same as checkingUP, but torrent has NOT finished downloading
+	StateForcedDL           TorrentState = "forcedDL"           // Torrent is forced to downloading to ignore queue limit
+	StateCheckingResumeData TorrentState = "checkingResumeData" // Checking resume data on qBittorrent startup
+	StateMoving             TorrentState = "moving"             // Torrent is moving to another location
+	StateUnknown            TorrentState = "unknown"            // Unknown status
+)
+
+// IsDownloading reports whether the torrent has not finished downloading and is not paused or errored.
+func (s TorrentState) IsDownloading() bool {
+	switch s {
+	case StateAllocating, StateDownloading, StateMetaDL, StateQueuedDL, StateStalledDL, StateCheckingDL, StateForcedDL:
+		return true
+	}
+	return false
+}
+
+// IsSeeding reports whether the torrent has finished downloading and is not paused or errored.
+func (s TorrentState) IsSeeding() bool {
+	switch s {
+	case StateUploading, StateQueuedUP, StateStalledUP, StateCheckingUP, StateForcedUP:
+		return true
+	}
+	return false
+}
+
+// IsPaused reports whether the torrent is paused, or stopped as qBittorrent 5.0 and later calls it.
+func (s TorrentState) IsPaused() bool {
+	switch s {
+	case StatePausedDL, StatePausedUP, StateStoppedDL, StateStoppedUP:
+		return true
+	}
+	return false
+}
+
+// IsStalled reports whether no connections are made for the torrent.
+func (s TorrentState) IsStalled() bool {
+	return s == StateStalledDL || s == StateStalledUP
+}
+
+// IsErrored reports whether the torrent is stopped because of an error.
+func (s TorrentState) IsErrored() bool {
+	return s == StateError || s == StateMissingFiles
+}
+
+// IsChecking reports whether the data or resume data of the torrent is being checked.
+func (s TorrentState) IsChecking() bool {
+	return s == StateCheckingDL || s == StateCheckingUP || s == StateCheckingResumeData
+}
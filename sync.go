@@ -0,0 +1,224 @@
+package qbit
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+type ServerState struct {
+	AlltimeDl            int64            `json:"alltime_dl"`             // Data downloaded in total (bytes)
+	AlltimeUl            int64            `json:"alltime_ul"`             // Data uploaded in total (bytes)
+	AverageTimeQueue     int64            `json:"average_time_queue"`     // Average time in the disk queue (ms)
+	ConnectionStatus     ConnectionStatus `json:"connection_status"`      // Connection status
+	DhtNodes             int32            `json:"dht_nodes"`              // DHT nodes connected to
+	DlInfoData           int64            `json:"dl_info_data"`           // Data downloaded this session (bytes)
+	DlInfoSpeed          int64            `json:"dl_info_speed"`          // Global download rate (bytes/s)
+	DlRateLimit          int64            `json:"dl_rate_limit"`          // Download rate limit (bytes/s)
+	FreeSpaceOnDisk      int64            `json:"free_space_on_disk"`     // Free space in the default save path (bytes)
+	GlobalRatio          string           `json:"global_ratio"`           // Global share ratio
+	QueuedIoJobs         int64            `json:"queued_io_jobs"`         // Number of queued disk jobs
+	Queueing             bool             `json:"queueing"`               // True if torrent queueing is enabled
+	ReadCacheHits        string           `json:"read_cache_hits"`        // Percentage of reads served from the cache
+	ReadCacheOverload    string           `json:"read_cache_overload"`    // Read cache overload percentage
+	RefreshInterval      int32            `json:"refresh_interval"`       // WebUI refresh interval (ms)
+	TotalBuffersSize     int64            `json:"total_buffers_size"`     // Size of the disk buffers (bytes)
+	TotalPeerConnections int32            `json:"total_peer_connections"` // Number of peers connected to
+	TotalQueuedSize      int64            `json:"total_queued_size"`      // Data queued for disk writes (bytes)
+	TotalWastedSession   int64            `json:"total_wasted_session"`   // Data wasted this session (bytes)
+	UpInfoData           int64            `json:"up_info_data"`           // Data uploaded this session (bytes)
+	UpInfoSpeed          int64            `json:"up_info_speed"`          // Global upload rate (bytes/s)
+	UpRateLimit          int64            `json:"up_rate_limit"`          // Upload rate limit (bytes/s)
+	UseAltSpeedLimits    bool             `json:"use_alt_speed_limits"`   // True if the alternative speed limits are active
+	WriteCacheOverload   string           `json:"write_cache_overload"`   // Write cache overload percentage
+}
+
+// Snapshot is the state of qBittorrent built from the sync updates.
+type Snapshot struct {
+	Torrents    map[string]TorrentInfo // Torrents by hash
+	Categories  map[string]Category    // Categories by name
+	Tags        []string               // All tags
+	ServerState ServerState            // Global state
+}
+
+// MainData is a /sync/maindata answer. Unless FullUpdate is set it only
+// contains what changed since the rid it was requested with, and the torrents
+// only have their changed fields set apart from Hash.
+type MainData struct {
+	Rid               int                    `json:"rid"`                // Response ID to pass to the next call
+	FullUpdate        bool                   `json:"full_update"`        // True if this is the full state instead of the changes
+	Torrents          map[string]TorrentInfo `json:"torrents"`           // Added or changed torrents by hash
+	TorrentsRemoved   []string               `json:"torrents_removed"`   // Hashes of removed torrents
+	Categories        map[string]Category    `json:"categories"`         // Added or changed categories by name
+	CategoriesRemoved []string               `json:"categories_removed"` // Names of removed categories
+	Tags              []string               `json:"tags"`               // Added tags
+	TagsRemoved       []string               `json:"tags_removed"`       // Removed tags
+	ServerState       ServerState            `json:"server_state"`       // Global state, only the changed fields unless FullUpdate
+}
+
+// GetMaindata returns the changes since rid, or the full state for a rid of 0.
+// Use a SyncClient to have the changes merged into a complete state.
+func (c *Client) GetMaindata(ctx context.Context, rid int) (*MainData, error) {
+	var data MainData
+	var query = url.Values{"rid": {strconv.Itoa(rid)}}
+	if err := c.getJson(ctx, "/api/v2/sync/maindata", query, &data); err != nil {
+		return nil, err
+	}
+
+	for hash, torrent := range data.Torrents {
+		torrent.Hash = hash
+		data.Torrents[hash] = torrent
+	}
+	return &data, nil
+}
+
+// syncUpdate is a /sync/maindata answer. Apart from a full update the objects
+// only contain the fields that changed, so they are kept raw and merged onto
+// the previous values.
+type syncUpdate struct {
+	Rid               int                        `json:"rid"`
+	FullUpdate        bool                       `json:"full_update"`
+	Torrents          map[string]json.RawMessage `json:"torrents"`
+	TorrentsRemoved   []string                   `json:"torrents_removed"`
+	Categories        map[string]json.RawMessage `json:"categories"`
+	CategoriesRemoved []string                   `json:"categories_removed"`
+	Tags              []string                   `json:"tags"`
+	TagsRemoved       []string                   `json:"tags_removed"`
+	ServerState       json.RawMessage            `json:"server_state"`
+}
+
+// SyncClient keeps a Snapshot of qBittorrent up to date by only requesting what
+// changed since the previous Sync. It is safe for concurrent use.
+type SyncClient struct {
+	client   *Client
+	mu       sync.Mutex
+	rid      int
+	snapshot Snapshot
+}
+
+// NewSyncClient creates a SyncClient, the first Sync fetches the full state.
+func (c *Client) NewSyncClient() *SyncClient {
+	return &SyncClient{client: c, snapshot: emptySnapshot()}
+}
+
+// Sync fetches the changes since the previous Sync and merges them into the snapshot.
+func (s *SyncClient) Sync(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var update syncUpdate
+	var query = url.Values{"rid": {strconv.Itoa(s.rid)}}
+	if err := s.client.getJson(ctx, "/api/v2/sync/maindata", query, &update); err != nil {
+		return err
+	}
+
+	if err := s.merge(&update); err != nil {
+		return err
+	}
+	s.rid = update.Rid
+	return nil
+}
+
+// Snapshot returns a copy of the current state.
+func (s *SyncClient) Snapshot() Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var snapshot = Snapshot{
+		Torrents:    make(map[string]TorrentInfo, len(s.snapshot.Torrents)),
+		Categories:  make(map[string]Category, len(s.snapshot.Categories)),
+		Tags:        append([]string(nil), s.snapshot.Tags...),
+		ServerState: s.snapshot.ServerState,
+	}
+	for hash, torrent := range s.snapshot.Torrents {
+		snapshot.Torrents[hash] = torrent
+	}
+	for name, category := range s.snapshot.Categories {
+		snapshot.Categories[name] = category
+	}
+	return snapshot
+}
+
+// Reset drops the snapshot so that the next Sync fetches the full state again.
+func (s *SyncClient) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rid = 0
+	s.snapshot = emptySnapshot()
+}
+
+func (s *SyncClient) merge(update *syncUpdate) error {
+	if update.FullUpdate {
+		s.snapshot = emptySnapshot()
+	}
+
+	for hash, raw := range update.Torrents {
+		var torrent = s.snapshot.Torrents[hash]
+		if err := json.Unmarshal(raw, &torrent); err != nil {
+			return err
+		}
+		torrent.Hash = hash
+		s.snapshot.Torrents[hash] = torrent
+	}
+	for _, hash := range update.TorrentsRemoved {
+		delete(s.snapshot.Torrents, hash)
+	}
+
+	for name, raw := range update.Categories {
+		var category = s.snapshot.Categories[name]
+		if err := json.Unmarshal(raw, &category); err != nil {
+			return err
+		}
+		s.snapshot.Categories[name] = category
+	}
+	for _, name := range update.CategoriesRemoved {
+		delete(s.snapshot.Categories, name)
+	}
+
+	s.snapshot.Tags = mergeTags(s.snapshot.Tags, update.Tags, update.TagsRemoved)
+
+	if len(update.ServerState) > 0 {
+		if err := json.Unmarshal(update.ServerState, &s.snapshot.ServerState); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mergeTags(tags, added, removed []string) []string {
+	var known = make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		known[tag] = true
+	}
+	for _, tag := range added {
+		if !known[tag] {
+			known[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(removed) == 0 {
+		return tags
+	}
+	var isRemoved = make(map[string]bool, len(removed))
+	for _, tag := range removed {
+		isRemoved[tag] = true
+	}
+	var kept = tags[:0]
+	for _, tag := range tags {
+		if !isRemoved[tag] {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+func emptySnapshot() Snapshot {
+	return Snapshot{
+		Torrents:   map[string]TorrentInfo{},
+		Categories: map[string]Category{},
+	}
+}
@@ -0,0 +1,88 @@
+package qbit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSyncClientMergesPartialUpdates(t *testing.T) {
+	var updates = []string{
+		`{"rid":1,"full_update":true,
+			"torrents":{
+				"aaa":{"name":"first","state":"downloading","progress":0.5,"dlspeed":1000,"category":"movies"},
+				"bbb":{"name":"second","state":"pausedUP","progress":1}},
+			"categories":{"movies":{"name":"movies","savePath":"/movies"},"tv":{"name":"tv","savePath":"/tv"}},
+			"tags":["a","b"],
+			"server_state":{"connection_status":"connected","dl_info_speed":1000,"up_info_speed":50}}`,
+		`{"rid":2,
+			"torrents":{"aaa":{"progress":0.75,"dlspeed":2000}},
+			"server_state":{"dl_info_speed":2000}}`,
+		`{"rid":3,
+			"torrents":{"aaa":{"state":"stalledUP","progress":1},"ccc":{"name":"third","state":"queuedDL"}},
+			"torrents_removed":["bbb"],
+			"categories":{"movies":{"savePath":"/films"}},
+			"categories_removed":["tv"],
+			"tags":["c"],
+			"tags_removed":["a"]}`,
+	}
+
+	var rids []string
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		rids = append(rids, r.URL.Query().Get("rid"))
+		_, _ = w.Write([]byte(updates[len(rids)-1]))
+	})
+	syncClient := newTestClient(t, s).NewSyncClient()
+	for range updates {
+		if err := syncClient.Sync(context.Background()); err != nil {
+			t.Fatalf("Sync: %v", err)
+		}
+	}
+
+	if want := []string{"0", "1", "2"}; !reflect.DeepEqual(rids, want) {
+		t.Errorf("requested rids %v, want %v", rids, want)
+	}
+
+	var want = Snapshot{
+		Torrents: map[string]TorrentInfo{
+			"aaa": {Hash: "aaa", Name: "first", State: StateStalledUP, Progress: 1, Dlspeed: 2000, Category: "movies"},
+			"ccc": {Hash: "ccc", Name: "third", State: StateQueuedDL},
+		},
+		Categories: map[string]Category{
+			"movies": {Name: "movies", SavePath: "/films"},
+		},
+		Tags:        []string{"b", "c"},
+		ServerState: ServerState{ConnectionStatus: "connected", DlInfoSpeed: 2000, UpInfoSpeed: 50},
+	}
+	if got := syncClient.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got snapshot\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestSyncClientFullUpdateReplacesSnapshot(t *testing.T) {
+	var updates = []string{
+		`{"rid":1,"full_update":true,"torrents":{"aaa":{"name":"first"}},"tags":["a"]}`,
+		`{"rid":2,"full_update":true,"torrents":{"bbb":{"name":"second"}}}`,
+	}
+
+	var calls int
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(updates[calls-1]))
+	})
+	syncClient := newTestClient(t, s).NewSyncClient()
+	for range updates {
+		if err := syncClient.Sync(context.Background()); err != nil {
+			t.Fatalf("Sync: %v", err)
+		}
+	}
+
+	snapshot := syncClient.Snapshot()
+	if _, ok := snapshot.Torrents["aaa"]; ok || len(snapshot.Torrents) != 1 {
+		t.Errorf("expected only the torrents of the last full update, got %v", snapshot.Torrents)
+	}
+	if len(snapshot.Tags) != 0 {
+		t.Errorf("expected no tags, got %v", snapshot.Tags)
+	}
+}
@@ -0,0 +1,77 @@
+package qbit
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// GetTags returns all tags.
+func (c *Client) GetTags(ctx context.Context) (tags []string, err error) {
+	err = c.getJson(ctx, "/api/v2/torrents/tags", nil, &tags)
+	return
+}
+
+// CreateTags creates new tags.
+func (c *Client) CreateTags(ctx context.Context, tags []string) error {
+	return c.postAction(ctx, "/api/v2/torrents/createTags", tagsForm(tags))
+}
+
+// DeleteTags deletes tags, removing them from all torrents.
+func (c *Client) DeleteTags(ctx context.Context, tags []string) error {
+	return c.postAction(ctx, "/api/v2/torrents/deleteTags", tagsForm(tags))
+}
+
+// AddTorrentTags adds tags to the torrents with the given hashes, creating the tags if needed.
+func (c *Client) AddTorrentTags(ctx context.Context, hashes, tags []string) error {
+	return c.postTorrentTags(ctx, "/api/v2/torrents/addTags", hashes, tags)
+}
+
+// RemoveTorrentTags removes tags from the torrents with the given hashes.
+func (c *Client) RemoveTorrentTags(ctx context.Context, hashes, tags []string) error {
+	return c.postTorrentTags(ctx, "/api/v2/torrents/removeTags", hashes, tags)
+}
+
+func (c *Client) postTorrentTags(ctx context.Context, path string, hashes, tags []string) error {
+	form, err := hashesForm(hashes)
+	if err != nil {
+		return err
+	}
+	form.Set("tags", strings.Join(tags, ","))
+	return c.postAction(ctx, path, form)
+}
+
+func tagsForm(tags []string) url.Values {
+	var form = url.Values{}
+	form.Set("tags", strings.Join(tags, ","))
+	return form
+}
+
+// ParseTorrentTags splits the comma separated tags of a torrent.
+//noinspection GoUnusedExportedFunction
+func ParseTorrentTags(t *TorrentInfo) []string {
+	return t.TagList()
+}
+
+// TagList splits the comma separated Tags, trimming the space qBittorrent puts after each comma.
+func (t *TorrentInfo) TagList() []string {
+	if strings.TrimSpace(t.Tags) == "" {
+		return nil
+	}
+
+	var tags = strings.Split(t.Tags, ",")
+	for i, tag := range tags {
+		tags[i] = strings.TrimSpace(tag)
+	}
+	return tags
+}
+
+// HasTag reports whether the torrent is tagged with tag.
+func (t *TorrentInfo) HasTag(tag string) bool {
+	for _, other := range t.TagList() {
+		if other == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package qbit
+
+import (
+	"math"
+	"time"
+)
+
+// InfiniteETA is the ETADuration of torrents qBittorrent cannot estimate an ETA for.
+const InfiniteETA time.Duration = math.MaxInt64
+
+// etaInfiniteSeconds is the eta qBittorrent reports when it has no estimate (100 days).
+const etaInfiniteSeconds = 8640000
+
+// AddedTime returns when the torrent was added.
+func (t *TorrentInfo) AddedTime() time.Time {
+	return epochTime(t.AddedOn)
+}
+
+// CompletionTime returns when the torrent completed, or the zero time if it has not.
+func (t *TorrentInfo) CompletionTime() time.Time {
+	return epochTime(t.CompletionOn)
+}
+
+// LastActivityTime returns when a chunk was last downloaded or uploaded, or
+// the zero time if never.
+func (t *TorrentInfo) LastActivityTime() time.Time {
+	return epochTime(t.LastActivity)
+}
+
+// SeenCompleteTime returns when the torrent was last seen complete, or the
+// zero time if never.
+func (t *TorrentInfo) SeenCompleteTime() time.Time {
+	return epochTime(t.SeenComplete)
+}
+
+// ETADuration returns the estimated time until the torrent has finished
+// downloading, or InfiniteETA if there is no estimate.
+func (t *TorrentInfo) ETADuration() time.Duration {
+	if t.Eta >= etaInfiniteSeconds {
+		return InfiniteETA
+	}
+	return time.Duration(t.Eta) * time.Second
+}
+
+// ActiveDuration returns the total time the torrent has been active.
+func (t *TorrentInfo) ActiveDuration() time.Duration {
+	return time.Duration(t.TimeActive) * time.Second
+}
+
+// Age returns how long ago the torrent was added.
+func (t *TorrentInfo) Age() time.Duration {
+	return since(t.AddedTime())
+}
+
+// InactiveFor returns how long ago a chunk was last downloaded or uploaded,
+// or the Age of the torrent if there has been no activity.
+func (t *TorrentInfo) InactiveFor() time.Duration {
+	var last = t.LastActivityTime()
+	if last.IsZero() {
+		return t.Age()
+	}
+	return since(last)
+}
+
+// epochTime converts a Unix epoch from qBittorrent to a time, where 0 and -1
+// mean there is no time.
+func epochTime(epoch int64) time.Time {
+	if epoch <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(epoch, 0)
+}
+
+// since is time.Since, but zero for the zero time.
+func since(t time.Time) time.Duration {
+	if t.IsZero() {
+		return 0
+	}
+	return time.Since(t)
+}
@@ -0,0 +1,72 @@
+package qbit
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTimeSentinels(t *testing.T) {
+	var torrent TorrentInfo
+	err := json.Unmarshal([]byte(`{"added_on":0,"completion_on":-1,"last_activity":0,"seen_complete":-1,"eta":8640000,"time_active":0}`), &torrent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, got := range map[string]time.Time{
+		"AddedTime":        torrent.AddedTime(),
+		"CompletionTime":   torrent.CompletionTime(),
+		"LastActivityTime": torrent.LastActivityTime(),
+		"SeenCompleteTime": torrent.SeenCompleteTime(),
+	} {
+		if !got.IsZero() {
+			t.Errorf("%s: got %s, want the zero time", name, got)
+		}
+	}
+	if got := torrent.ETADuration(); got != InfiniteETA {
+		t.Errorf("got ETA %s, want InfiniteETA", got)
+	}
+	if got := torrent.Age(); got != 0 {
+		t.Errorf("got age %s, want 0", got)
+	}
+	if got := torrent.InactiveFor(); got != 0 {
+		t.Errorf("got inactive for %s, want 0", got)
+	}
+}
+
+func TestTimes(t *testing.T) {
+	var now = time.Now().Unix()
+	var torrent TorrentInfo
+	err := json.Unmarshal([]byte(`{
+		"added_on":`+strconv.FormatInt(now-3600, 10)+`,"completion_on":1581240000,"last_activity":`+strconv.FormatInt(now-60, 10)+`,
+		"seen_complete":1581300000,"eta":90,"time_active":7200}`), &torrent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := torrent.CompletionTime(); !got.Equal(time.Unix(1581240000, 0)) {
+		t.Errorf("got completion time %s", got)
+	}
+	if got := torrent.SeenCompleteTime(); !got.Equal(time.Unix(1581300000, 0)) {
+		t.Errorf("got seen complete time %s", got)
+	}
+	if got := torrent.ETADuration(); got != 90*time.Second {
+		t.Errorf("got ETA %s, want 1m30s", got)
+	}
+	if got := torrent.ActiveDuration(); got != 2*time.Hour {
+		t.Errorf("got active duration %s, want 2h", got)
+	}
+	if got := torrent.Age(); got < time.Hour || got > time.Hour+time.Minute {
+		t.Errorf("got age %s, want about 1h", got)
+	}
+	if got := torrent.InactiveFor(); got < time.Minute || got > 2*time.Minute {
+		t.Errorf("got inactive for %s, want about 1m", got)
+	}
+
+	// Without activity the torrent has been inactive since it was added
+	torrent.LastActivity = 0
+	if got := torrent.InactiveFor(); got < time.Hour {
+		t.Errorf("got inactive for %s, want the age", got)
+	}
+}
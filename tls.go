@@ -0,0 +1,49 @@
+package qbit
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+)
+
+// customTLS reports whether the TLS configuration differs from the default.
+func (cfg *clientConfig) customTLS() bool {
+	return cfg.tlsConfig != nil || cfg.caCertPath != "" || cfg.clientCertPath != "" || cfg.insecureSkipVerify
+}
+
+// buildTLSConfig combines WithTLSConfig with the certificate options.
+func (cfg *clientConfig) buildTLSConfig() (*tls.Config, error) {
+	var tlsConfig = &tls.Config{}
+	if cfg.tlsConfig != nil {
+		tlsConfig = cfg.tlsConfig.Clone()
+	}
+
+	if cfg.caCertPath != "" {
+		pem, err := ioutil.ReadFile(cfg.caCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificate: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, &Error{Message: fmt.Sprintf("no certificates found in CA certificate %s", cfg.caCertPath)}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.clientCertPath != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.clientCertPath, cfg.clientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+
+	if cfg.insecureSkipVerify {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	return tlsConfig, nil
+}
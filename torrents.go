@@ -0,0 +1,171 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ErrIncomplete is returned when enabling super seeding for a torrent that has not finished downloading.
+var ErrIncomplete = errors.New("torrent has not finished downloading")
+
+// AllTorrents can be passed as a hash to act on every torrent.
+const AllTorrents = "all"
+
+// PauseTorrents pauses the torrents with the given hashes.
+func (c *Client) PauseTorrents(ctx context.Context, hashes []string) error {
+	return c.postHashes(ctx, "/api/v2/torrents/pause", hashes)
+}
+
+// PauseTorrent pauses a single torrent.
+func (c *Client) PauseTorrent(ctx context.Context, hash string) error {
+	return c.PauseTorrents(ctx, []string{hash})
+}
+
+// ResumeTorrents resumes the torrents with the given hashes.
+func (c *Client) ResumeTorrents(ctx context.Context, hashes []string) error {
+	return c.postHashes(ctx, "/api/v2/torrents/resume", hashes)
+}
+
+// ResumeTorrent resumes a single torrent.
+func (c *Client) ResumeTorrent(ctx context.Context, hash string) error {
+	return c.ResumeTorrents(ctx, []string{hash})
+}
+
+// SetForceStart enables or disables force start of the torrents with the given hashes.
+func (c *Client) SetForceStart(ctx context.Context, hashes []string, value bool) error {
+	return c.postFlag(ctx, "/api/v2/torrents/setForceStart", hashes, "value", value, nil)
+}
+
+// SetSuperSeeding enables or disables super seeding of the torrents with the
+// given hashes. Only completed torrents can super seed, ErrIncomplete is
+// returned if qBittorrent refuses it for an incomplete one.
+func (c *Client) SetSuperSeeding(ctx context.Context, hashes []string, value bool) error {
+	return c.postFlag(ctx, "/api/v2/torrents/setSuperSeeding", hashes, "value", value, statusErrors{
+		http.StatusBadRequest: ErrIncomplete,
+	})
+}
+
+// SetAutoManagement enables or disables Automatic Torrent Management of the
+// torrents with the given hashes. ATM takes the save path from the category,
+// but enabling it for torrents without a category is accepted by qBittorrent,
+// so category membership is not checked here.
+func (c *Client) SetAutoManagement(ctx context.Context, hashes []string, enable bool) error {
+	return c.postFlag(ctx, "/api/v2/torrents/setAutoManagement", hashes, "enable", enable, nil)
+}
+
+// postFlag posts the hashes together with a boolean field to path.
+func (c *Client) postFlag(ctx context.Context, path string, hashes []string, field string, value bool, errs statusErrors) error {
+	form, err := hashesForm(hashes)
+	if err != nil {
+		return err
+	}
+	form.Set(field, strconv.FormatBool(value))
+	return c.postActionErrors(ctx, path, form, errs)
+}
+
+// ToggleSequentialDownload toggles sequential download of the torrents with
+// the given hashes, or of every torrent with AllTorrents. It is a toggle, so
+// calling it twice returns the torrents to their original state.
+func (c *Client) ToggleSequentialDownload(ctx context.Context, hashes []string) error {
+	return c.postHashes(ctx, "/api/v2/torrents/toggleSequentialDownload", hashes)
+}
+
+// ToggleFirstLastPiecePriority toggles the prioritization of the first and
+// last pieces of the torrents with the given hashes, or of every torrent with
+// AllTorrents. It is a toggle, so calling it twice returns the torrents to
+// their original state.
+func (c *Client) ToggleFirstLastPiecePriority(ctx context.Context, hashes []string) error {
+	return c.postHashes(ctx, "/api/v2/torrents/toggleFirstLastPiecePrio", hashes)
+}
+
+// ToggleFirstLastPiecePrio is ToggleFirstLastPiecePriority under the name of the qBittorrent endpoint.
+func (c *Client) ToggleFirstLastPiecePrio(ctx context.Context, hashes []string) error {
+	return c.ToggleFirstLastPiecePriority(ctx, hashes)
+}
+
+// SetSequentialDownload enables or disables sequential download of a torrent.
+// qBittorrent can only toggle it, so the torrent is looked up first and only
+// toggled if it differs from enabled.
+func (c *Client) SetSequentialDownload(ctx context.Context, hash string, enabled bool) error {
+	torrent, err := c.GetTorrentByHash(ctx, hash)
+	if err != nil {
+		return err
+	}
+	if torrent.SeqDl == enabled {
+		return nil
+	}
+	return c.ToggleSequentialDownload(ctx, []string{hash})
+}
+
+// RecheckTorrents makes qBittorrent verify the downloaded data of the torrents with the given hashes.
+func (c *Client) RecheckTorrents(ctx context.Context, hashes []string) error {
+	if err := c.postHashes(ctx, "/api/v2/torrents/recheck", hashes); err != nil {
+		return err
+	}
+
+	rechecksMade.Inc()
+	c.logger.Info("Successfully rechecked %v", hashes)
+	return nil
+}
+
+// RecheckAllTorrents makes qBittorrent verify the downloaded data of every torrent.
+func (c *Client) RecheckAllTorrents(ctx context.Context) error {
+	return c.RecheckTorrents(ctx, []string{AllTorrents})
+}
+
+// RecheckTorrent makes qBittorrent verify the downloaded data of a single torrent.
+func (c *Client) RecheckTorrent(ctx context.Context, hash string) error {
+	return c.RecheckTorrents(ctx, []string{hash})
+}
+
+// DeleteTorrents removes the torrents with the given hashes, and their
+// downloaded data if deleteFiles is true. To not delete everything by mistake,
+// AllTorrents is refused, use DeleteAllTorrents for that.
+func (c *Client) DeleteTorrents(ctx context.Context, hashes []string, deleteFiles bool) error {
+	for _, hash := range hashes {
+		if hash == AllTorrents {
+			return &Error{Message: "DeleteTorrents does not accept all, use DeleteAllTorrents"}
+		}
+	}
+	return c.deleteTorrents(ctx, hashes, deleteFiles)
+}
+
+// DeleteAllTorrents removes every torrent, and all downloaded data if deleteFiles is true.
+func (c *Client) DeleteAllTorrents(ctx context.Context, deleteFiles bool) error {
+	return c.deleteTorrents(ctx, []string{AllTorrents}, deleteFiles)
+}
+
+func (c *Client) deleteTorrents(ctx context.Context, hashes []string, deleteFiles bool) error {
+	form, err := hashesForm(hashes)
+	if err != nil {
+		return err
+	}
+	form.Set("deleteFiles", strconv.FormatBool(deleteFiles))
+	return c.postAction(ctx, "/api/v2/torrents/delete", form)
+}
+
+// DeleteTorrent removes a single torrent.
+func (c *Client) DeleteTorrent(ctx context.Context, hash string, deleteFiles bool) error {
+	return c.DeleteTorrents(ctx, []string{hash}, deleteFiles)
+}
+
+func (c *Client) postHashes(ctx context.Context, path string, hashes []string) error {
+	form, err := hashesForm(hashes)
+	if err != nil {
+		return err
+	}
+	return c.postAction(ctx, path, form)
+}
+
+func hashesForm(hashes []string) (url.Values, error) {
+	if len(hashes) == 0 {
+		return nil, ErrNoHashes
+	}
+
+	var form = url.Values{}
+	form.Set("hashes", combineHashes(hashes))
+	return form, nil
+}
@@ -0,0 +1,67 @@
+package qbit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestTorrentActionsEncodeHashes(t *testing.T) {
+	var hashes = []string{"aaa", "bbb", "ccc"}
+	var tests = []struct {
+		name string
+		call func(c *Client) error
+		path string
+		form url.Values
+	}{
+		{
+			name: "pause",
+			call: func(c *Client) error { return c.PauseTorrents(context.Background(), hashes) },
+			path: "/api/v2/torrents/pause",
+			form: url.Values{"hashes": {"aaa|bbb|ccc"}},
+		},
+		{
+			name: "resume",
+			call: func(c *Client) error { return c.ResumeTorrents(context.Background(), hashes) },
+			path: "/api/v2/torrents/resume",
+			form: url.Values{"hashes": {"aaa|bbb|ccc"}},
+		},
+		{
+			name: "force start",
+			call: func(c *Client) error { return c.SetForceStart(context.Background(), hashes, true) },
+			path: "/api/v2/torrents/setForceStart",
+			form: url.Values{"hashes": {"aaa|bbb|ccc"}, "value": {"true"}},
+		},
+		{
+			name: "force start disabled",
+			call: func(c *Client) error { return c.SetForceStart(context.Background(), hashes, false) },
+			path: "/api/v2/torrents/setForceStart",
+			form: url.Values{"hashes": {"aaa|bbb|ccc"}, "value": {"false"}},
+		},
+		{
+			name: "pause all",
+			call: func(c *Client) error { return c.PauseTorrents(context.Background(), []string{AllTorrents}) },
+			path: "/api/v2/torrents/pause",
+			form: url.Values{"hashes": {"all"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, rec := newRecordingClient(t, "")
+
+			if err := test.call(c); err != nil {
+				t.Fatal(err)
+			}
+
+			req := rec.only(t)
+			if req.Method != http.MethodPost || req.Path != test.path {
+				t.Errorf("got %s %s, want POST %s", req.Method, req.Path, test.path)
+			}
+			if !reflect.DeepEqual(req.Form, test.form) {
+				t.Errorf("got form %v, want %v", req.Form, test.form)
+			}
+		})
+	}
+}
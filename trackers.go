@@ -0,0 +1,136 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TrackerStatus is the status of a tracker as reported in TrackerInfo.Status.
+type TrackerStatus int
+
+//noinspection GoUnusedConst
+const (
+	TrackerDisabled     TrackerStatus = 0 // Tracker is disabled (used for DHT, PeX, and LSD)
+	TrackerNotContacted TrackerStatus = 1 // Tracker has not been contacted yet
+	TrackerWorking      TrackerStatus = 2 // Tracker has been contacted and is working
+	TrackerUpdating     TrackerStatus = 3 // Tracker is updating
+	TrackerNotWorking   TrackerStatus = 4 // Tracker has been contacted, but it is not working (or doesn't send proper replies)
+)
+
+func (s TrackerStatus) String() string {
+	switch s {
+	case TrackerDisabled:
+		return "disabled"
+	case TrackerNotContacted:
+		return "not contacted"
+	case TrackerWorking:
+		return "working"
+	case TrackerUpdating:
+		return "updating"
+	case TrackerNotWorking:
+		return "not working"
+	}
+	return fmt.Sprintf("TrackerStatus(%d)", int(s))
+}
+
+// IsReal reports whether the tracker is an actual tracker and not one of the
+// DHT, PeX and LSD entries qBittorrent always lists.
+func (t *TrackerInfo) IsReal() bool {
+	return !strings.HasPrefix(t.Url, "** [")
+}
+
+// Trackers are the trackers of a torrent.
+type Trackers []TrackerInfo
+
+// HasWorkingTracker reports whether any real tracker is working.
+func (ts Trackers) HasWorkingTracker() bool {
+	for i := range ts {
+		if ts[i].IsReal() && ts[i].Status == TrackerWorking {
+			return true
+		}
+	}
+	return false
+}
+
+// AllTrackersDown reports whether there are real trackers and all of them
+// have been contacted without working.
+func (ts Trackers) AllTrackersDown() bool {
+	var real = 0
+	for i := range ts {
+		if !ts[i].IsReal() {
+			continue
+		}
+		if ts[i].Status != TrackerNotWorking {
+			return false
+		}
+		real++
+	}
+	return real > 0
+}
+
+// FirstRealTracker returns the first tracker that is not DHT, PeX or LSD, or
+// nil if there is none.
+func (ts Trackers) FirstRealTracker() *TrackerInfo {
+	for i := range ts {
+		if ts[i].IsReal() {
+			return &ts[i]
+		}
+	}
+	return nil
+}
+
+var (
+	// ErrTrackerNotFound is returned when a tracker to edit or remove is not a tracker of the torrent.
+	ErrTrackerNotFound = errors.New("tracker not found")
+	// ErrTrackerExists is returned when editing a tracker into one the torrent already has.
+	ErrTrackerExists = errors.New("tracker already exists")
+	// ErrNoURLs is returned when an action needing urls is given none.
+	ErrNoURLs = errors.New("no urls given")
+)
+
+// AddTrackers adds trackers to a torrent. ErrNoURLs is returned without
+// calling qBittorrent if there are no urls.
+func (c *Client) AddTrackers(ctx context.Context, hash string, urls []string) error {
+	if len(urls) == 0 {
+		return ErrNoURLs
+	}
+
+	var form = url.Values{}
+	form.Set("hash", hash)
+	form.Set("urls", strings.Join(urls, "\n"))
+	return c.postAction(ctx, "/api/v2/torrents/addTrackers", form)
+}
+
+// EditTracker replaces the tracker origUrl of a torrent with newUrl.
+// ErrTrackerNotFound is returned if origUrl is not a tracker of the torrent,
+// ErrTrackerExists if newUrl already is.
+func (c *Client) EditTracker(ctx context.Context, hash, origUrl, newUrl string) error {
+	var form = url.Values{}
+	form.Set("hash", hash)
+	form.Set("origUrl", origUrl)
+	form.Set("newUrl", newUrl)
+	return c.postActionErrors(ctx, "/api/v2/torrents/editTracker", form, statusErrors{
+		http.StatusBadRequest: ErrTrackerNotFound,
+		http.StatusConflict:   ErrTrackerExists,
+	})
+}
+
+// RemoveTrackers removes trackers from a torrent. ErrTrackerNotFound is
+// returned if none of the urls are trackers of the torrent, ErrNoURLs without
+// calling qBittorrent if there are no urls.
+func (c *Client) RemoveTrackers(ctx context.Context, hash string, urls []string) error {
+	if len(urls) == 0 {
+		return ErrNoURLs
+	}
+
+	var form = url.Values{}
+	form.Set("hash", hash)
+	form.Set("urls", strings.Join(urls, "|"))
+	return c.postActionErrors(ctx, "/api/v2/torrents/removeTrackers", form, statusErrors{
+		http.StatusConflict: ErrTrackerNotFound,
+	})
+}
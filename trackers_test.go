@@ -0,0 +1,85 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestTrackerUrlSeparators(t *testing.T) {
+	var urls = []string{"udp://tracker.example.org:1337/announce", "https://tracker.example.com/announce?passkey=a|b"}
+	var tests = []struct {
+		name string
+		call func(c *Client) error
+		path string
+		urls string
+	}{
+		{
+			name: "add",
+			call: func(c *Client) error { return c.AddTrackers(context.Background(), "aaa", urls) },
+			path: "/api/v2/torrents/addTrackers",
+			urls: "udp://tracker.example.org:1337/announce\nhttps://tracker.example.com/announce?passkey=a|b",
+		},
+		{
+			name: "remove",
+			call: func(c *Client) error { return c.RemoveTrackers(context.Background(), "aaa", urls[:1]) },
+			path: "/api/v2/torrents/removeTrackers",
+			urls: "udp://tracker.example.org:1337/announce",
+		},
+		{
+			name: "remove several",
+			call: func(c *Client) error {
+				return c.RemoveTrackers(context.Background(), "aaa", []string{"udp://a.example.org/announce", "udp://b.example.org/announce"})
+			},
+			path: "/api/v2/torrents/removeTrackers",
+			urls: "udp://a.example.org/announce|udp://b.example.org/announce",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, rec := newRecordingClient(t, "")
+
+			if err := test.call(c); err != nil {
+				t.Fatal(err)
+			}
+
+			req := rec.only(t)
+			if req.Path != test.path {
+				t.Errorf("got path %s, want %s", req.Path, test.path)
+			}
+			if got := req.Form.Get("hash"); got != "aaa" {
+				t.Errorf("got hash %q", got)
+			}
+			if got := req.Form.Get("urls"); got != test.urls {
+				t.Errorf("got urls %q, want %q", got, test.urls)
+			}
+		})
+	}
+}
+
+func TestRemoveTrackersNotFound(t *testing.T) {
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	c := newTestClient(t, s)
+
+	err := c.RemoveTrackers(context.Background(), "aaa", []string{"udp://unknown.example.org/announce"})
+	if !errors.Is(err, ErrTrackerNotFound) {
+		t.Errorf("got %v, want ErrTrackerNotFound", err)
+	}
+}
+
+func TestTrackerChangesWithoutUrls(t *testing.T) {
+	c, rec := newRecordingClient(t, "")
+
+	if err := c.AddTrackers(context.Background(), "aaa", nil); !errors.Is(err, ErrNoURLs) {
+		t.Errorf("AddTrackers: got %v, want ErrNoURLs", err)
+	}
+	if err := c.RemoveTrackers(context.Background(), "aaa", []string{}); !errors.Is(err, ErrNoURLs) {
+		t.Errorf("RemoveTrackers: got %v, want ErrNoURLs", err)
+	}
+	if len(rec.requests) != 0 {
+		t.Errorf("expected no requests, got %+v", rec.requests)
+	}
+}
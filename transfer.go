@@ -0,0 +1,107 @@
+package qbit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Unlimited is the speed limit meaning there is no limit.
+const Unlimited int64 = 0
+
+// ConnectionStatus is the connection status of qBittorrent.
+type ConnectionStatus string
+
+//noinspection GoUnusedConst
+const (
+	ConnectionConnected    ConnectionStatus = "connected"
+	ConnectionFirewalled   ConnectionStatus = "firewalled"
+	ConnectionDisconnected ConnectionStatus = "disconnected"
+)
+
+// IsConnected reports whether qBittorrent is connected, firewalled still counts as connected.
+func (s ConnectionStatus) IsConnected() bool {
+	return s == ConnectionConnected || s == ConnectionFirewalled
+}
+
+type TransferInfo struct {
+	ConnectionStatus ConnectionStatus `json:"connection_status"` // Connection status
+	DhtNodes         int32            `json:"dht_nodes"`         // DHT nodes connected to
+	DlInfoData       int64            `json:"dl_info_data"`      // Data downloaded this session (bytes)
+	DlInfoSpeed      int64            `json:"dl_info_speed"`     // Global download rate (bytes/s)
+	DlRateLimit      int64            `json:"dl_rate_limit"`     // Download rate limit (bytes/s)
+	UpInfoData       int64            `json:"up_info_data"`      // Data uploaded this session (bytes)
+	UpInfoSpeed      int64            `json:"up_info_speed"`     // Global upload rate (bytes/s)
+	UpRateLimit      int64            `json:"up_rate_limit"`     // Upload rate limit (bytes/s)
+}
+
+// GetTransferInfo returns the global transfer statistics of qBittorrent and
+// updates the global speed metrics.
+func (c *Client) GetTransferInfo(ctx context.Context) (*TransferInfo, error) {
+	var info TransferInfo
+	if err := c.getJson(ctx, "/api/v2/transfer/info", nil, &info); err != nil {
+		return nil, err
+	}
+
+	globalDownloadSpeed.WithLabelValues(c.instance()).Set(float64(info.DlInfoSpeed))
+	globalUploadSpeed.WithLabelValues(c.instance()).Set(float64(info.UpInfoSpeed))
+	return &info, nil
+}
+
+// GetGlobalDownloadLimit returns the global download limit (bytes/s) or Unlimited.
+func (c *Client) GetGlobalDownloadLimit(ctx context.Context) (int64, error) {
+	return c.getLimit(ctx, "/api/v2/transfer/downloadLimit")
+}
+
+// SetGlobalDownloadLimit sets the global download limit (bytes/s), use Unlimited to remove the limit.
+func (c *Client) SetGlobalDownloadLimit(ctx context.Context, bytesPerSec int64) error {
+	return c.setLimit(ctx, "/api/v2/transfer/setDownloadLimit", bytesPerSec)
+}
+
+// GetGlobalUploadLimit returns the global upload limit (bytes/s) or Unlimited.
+func (c *Client) GetGlobalUploadLimit(ctx context.Context) (int64, error) {
+	return c.getLimit(ctx, "/api/v2/transfer/uploadLimit")
+}
+
+// SetGlobalUploadLimit sets the global upload limit (bytes/s), use Unlimited to remove the limit.
+func (c *Client) SetGlobalUploadLimit(ctx context.Context, bytesPerSec int64) error {
+	return c.setLimit(ctx, "/api/v2/transfer/setUploadLimit", bytesPerSec)
+}
+
+// getLimit reads a limit endpoint, which answers with a bare integer instead of json.
+func (c *Client) getLimit(ctx context.Context, path string) (int64, error) {
+	body, err := c.getBody(ctx, path, nil)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(body)), 10, 64)
+}
+
+func (c *Client) setLimit(ctx context.Context, path string, bytesPerSec int64) error {
+	var form = url.Values{}
+	form.Set("limit", strconv.FormatInt(bytesPerSec, 10))
+	return c.postAction(ctx, path, form)
+}
+
+// GetSpeedLimitsMode reports whether the alternative speed limits are active.
+func (c *Client) GetSpeedLimitsMode(ctx context.Context) (bool, error) {
+	body, err := c.getBody(ctx, "/api/v2/transfer/speedLimitsMode", nil)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(body)) == "1", nil
+}
+
+// ToggleSpeedLimitsMode switches between the normal and the alternative speed
+// limits. Note that, unlike the other actions, qBittorrent documents this
+// endpoint as a GET even though it changes state, so it is never retried.
+func (c *Client) ToggleSpeedLimitsMode(ctx context.Context) error {
+	var path = "/api/v2/transfer/toggleSpeedLimitsMode"
+	resp, err := c.send(ctx, http.MethodGet, c.getUrl(path, nil), "", nil, false, nil)
+	if err != nil {
+		return err
+	}
+	return checkActionResponse(path, resp, nil)
+}
@@ -0,0 +1,52 @@
+package qbit
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestToggleSpeedLimitsModeIsNotRetried(t *testing.T) {
+	var calls int32
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/transfer/toggleSpeedLimitsMode" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	})
+	c := newTestClient(t, s)
+
+	err := c.ToggleSpeedLimitsMode(context.Background())
+	if err == nil {
+		t.Error("expected the 502 to be returned")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("toggled %d times, want 1", n)
+	}
+}
+
+func TestGetSpeedLimitsModeIsRetried(t *testing.T) {
+	var calls int32
+	s := newFakeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("1"))
+	})
+	c := newTestClient(t, s)
+
+	enabled, err := c.GetSpeedLimitsMode(context.Background())
+	if err != nil {
+		t.Fatalf("GetSpeedLimitsMode: %v", err)
+	}
+	if !enabled {
+		t.Error("expected the alternative speed limits to be enabled")
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("got %d calls, want 2", n)
+	}
+}
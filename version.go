@@ -0,0 +1,96 @@
+package qbit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidVersion is returned when a version string can not be parsed.
+var ErrInvalidVersion = errors.New("invalid version")
+
+// Version is a major.minor.patch version of qBittorrent or its WebAPI.
+type Version struct {
+	Major int // Major version
+	Minor int // Minor version
+	Patch int // Patch version, 0 if not given
+}
+
+// ParseVersion parses versions like "v4.6.3", "4.6.3beta1" and "2.8".
+// Anything after the patch number is ignored.
+func ParseVersion(s string) (v Version, err error) {
+	var parts = strings.SplitN(strings.TrimPrefix(strings.TrimSpace(s), "v"), ".", 3)
+	if len(parts) < 2 {
+		err = fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		return
+	}
+
+	var numbers = make([]int, 3)
+	for i, part := range parts {
+		// Drop suffixes such as "beta1" from the last part
+		var end = strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		if end >= 0 && i == len(parts)-1 {
+			part = part[:end]
+		}
+		if numbers[i], err = strconv.Atoi(part); err != nil {
+			err = fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+			return
+		}
+	}
+
+	v = Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}
+	return
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1 if v is older than other, 1 if it is newer and 0 if they are the same.
+func (v Version) Compare(other Version) int {
+	var a = [3]int{v.Major, v.Minor, v.Patch}
+	var b = [3]int{other.Major, other.Minor, other.Patch}
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast reports whether v is the given version or newer.
+func (v Version) AtLeast(major, minor, patch int) bool {
+	return v.Compare(Version{Major: major, Minor: minor, Patch: patch}) >= 0
+}
+
+// GetAPIVersion returns the WebAPI version, e.g. "2.8.3".
+func (c *Client) GetAPIVersion(ctx context.Context) (string, error) {
+	body, err := c.getBody(ctx, "/api/v2/app/webapiVersion", nil)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// GetParsedVersion returns the parsed qBittorrent version.
+func (c *Client) GetParsedVersion(ctx context.Context) (Version, error) {
+	return c.getParsedVersion(ctx, c.GetAppVersion)
+}
+
+// GetParsedAPIVersion returns the parsed WebAPI version.
+func (c *Client) GetParsedAPIVersion(ctx context.Context) (Version, error) {
+	return c.getParsedVersion(ctx, c.GetAPIVersion)
+}
+
+func (c *Client) getParsedVersion(ctx context.Context, get func(context.Context) (string, error)) (Version, error) {
+	s, err := get(ctx)
+	if err != nil {
+		return Version{}, err
+	}
+	return ParseVersion(s)
+}
@@ -0,0 +1,142 @@
+package qbit
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// TorrentEventType is the kind of change a TorrentEvent describes.
+type TorrentEventType int
+
+//noinspection GoUnusedConst
+const (
+	TorrentAdded        TorrentEventType = iota // The torrent was added, Before is nil
+	TorrentRemoved                              // The torrent was removed, After is nil
+	TorrentStateChanged                         // The state of the torrent changed
+	TorrentCompleted                            // The torrent finished downloading
+	TorrentStalled                              // The torrent became stalled
+	TorrentUnstalled                            // The torrent is no longer stalled
+)
+
+func (t TorrentEventType) String() string {
+	switch t {
+	case TorrentAdded:
+		return "added"
+	case TorrentRemoved:
+		return "removed"
+	case TorrentStateChanged:
+		return "state changed"
+	case TorrentCompleted:
+		return "completed"
+	case TorrentStalled:
+		return "stalled"
+	case TorrentUnstalled:
+		return "unstalled"
+	}
+	return "unknown"
+}
+
+// TorrentEvent is a change of a torrent seen by Watch.
+type TorrentEvent struct {
+	Type   TorrentEventType
+	Hash   string
+	Before *TorrentInfo
+	After  *TorrentInfo
+}
+
+// Watch polls qBittorrent every interval and sends the changes of the torrents
+// on the returned channel. Torrents that exist when Watch is called do not
+// cause any Added events. Events are sent ordered by hash, and per torrent in
+// the order of the TorrentEventType constants. The channel is closed once ctx
+// is done.
+func (c *Client) Watch(ctx context.Context, interval time.Duration) (<-chan TorrentEvent, error) {
+	if interval <= 0 {
+		return nil, &Error{Message: "the watch interval must be positive"}
+	}
+
+	var syncClient = c.NewSyncClient()
+	if err := syncClient.Sync(ctx); err != nil {
+		return nil, err
+	}
+
+	var events = make(chan TorrentEvent)
+	go func() {
+		defer close(events)
+
+		var ticker = time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var previous = syncClient.Snapshot().Torrents
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := syncClient.Sync(ctx); err != nil {
+				c.logger.Error("Failed to sync torrents: %s", err)
+				continue
+			}
+
+			var current = syncClient.Snapshot().Torrents
+			for _, event := range diffTorrents(previous, current) {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			previous = current
+		}
+	}()
+	return events, nil
+}
+
+func diffTorrents(previous, current map[string]TorrentInfo) (events []TorrentEvent) {
+	var hashes = make([]string, 0, len(previous)+len(current))
+	for hash := range previous {
+		hashes = append(hashes, hash)
+	}
+	for hash := range current {
+		if _, ok := previous[hash]; !ok {
+			hashes = append(hashes, hash)
+		}
+	}
+	sort.Strings(hashes)
+
+	for _, hash := range hashes {
+		before, hadBefore := previous[hash]
+		after, hasAfter := current[hash]
+		switch {
+		case !hadBefore:
+			events = append(events, TorrentEvent{Type: TorrentAdded, Hash: hash, After: &after})
+		case !hasAfter:
+			events = append(events, TorrentEvent{Type: TorrentRemoved, Hash: hash, Before: &before})
+		default:
+			events = append(events, torrentChanges(hash, &before, &after)...)
+		}
+	}
+	return
+}
+
+func torrentChanges(hash string, before, after *TorrentInfo) (events []TorrentEvent) {
+	add := func(eventType TorrentEventType) {
+		events = append(events, TorrentEvent{Type: eventType, Hash: hash, Before: before, After: after})
+	}
+
+	// Progress can reach 1 a poll before the state changes, so it is checked on its own
+	if before.State != after.State {
+		add(TorrentStateChanged)
+	}
+	if before.Progress < 1 && after.Progress >= 1 {
+		add(TorrentCompleted)
+	}
+	if !before.State.IsStalled() && after.State.IsStalled() {
+		add(TorrentStalled)
+	} else if before.State.IsStalled() && !after.State.IsStalled() {
+		add(TorrentUnstalled)
+	}
+	return
+}
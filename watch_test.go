@@ -0,0 +1,42 @@
+package qbit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffTorrentsCompletedBeforeStateChange(t *testing.T) {
+	var polls = []map[string]TorrentInfo{
+		{"a": {Hash: "a", State: StateDownloading, Progress: 0.99}},
+		{"a": {Hash: "a", State: StateDownloading, Progress: 1}},
+		{"a": {Hash: "a", State: StateStalledUP, Progress: 1}},
+	}
+
+	var got []TorrentEventType
+	for i := 1; i < len(polls); i++ {
+		for _, event := range diffTorrents(polls[i-1], polls[i]) {
+			got = append(got, event.Type)
+		}
+	}
+
+	var want = []TorrentEventType{TorrentCompleted, TorrentStateChanged, TorrentStalled}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+}
+
+func TestDiffTorrentsAddedAndRemoved(t *testing.T) {
+	var previous = map[string]TorrentInfo{"a": {Hash: "a"}}
+	var current = map[string]TorrentInfo{"b": {Hash: "b"}}
+
+	events := diffTorrents(previous, current)
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if events[0].Type != TorrentRemoved || events[0].Hash != "a" || events[0].After != nil {
+		t.Errorf("unexpected first event %+v", events[0])
+	}
+	if events[1].Type != TorrentAdded || events[1].Hash != "b" || events[1].Before != nil {
+		t.Errorf("unexpected second event %+v", events[1])
+	}
+}
@@ -0,0 +1,40 @@
+package qbit
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+type WebSeed struct {
+	URL string `json:"url"` // URL of the web seed
+}
+
+// GetWebSeeds returns the web seeds of a torrent.
+func (c *Client) GetWebSeeds(ctx context.Context, hash string) (seeds []WebSeed, err error) {
+	var query = url.Values{}
+	query.Set("hash", hash)
+	err = c.getJson(ctx, "/api/v2/torrents/webseeds", query, &seeds)
+	return
+}
+
+// AddWebSeeds adds web seeds to a torrent.
+func (c *Client) AddWebSeeds(ctx context.Context, hash string, urls []string) error {
+	return c.postWebSeeds(ctx, "/api/v2/torrents/addWebSeeds", hash, urls, "\n")
+}
+
+// RemoveWebSeeds removes web seeds from a torrent.
+func (c *Client) RemoveWebSeeds(ctx context.Context, hash string, urls []string) error {
+	return c.postWebSeeds(ctx, "/api/v2/torrents/removeWebSeeds", hash, urls, "|")
+}
+
+func (c *Client) postWebSeeds(ctx context.Context, path, hash string, urls []string, sep string) error {
+	if len(urls) == 0 {
+		return ErrNoURLs
+	}
+
+	var form = url.Values{}
+	form.Set("hash", hash)
+	form.Set("urls", strings.Join(urls, sep))
+	return c.postAction(ctx, path, form)
+}